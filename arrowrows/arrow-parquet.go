@@ -0,0 +1,59 @@
+package arrowrows
+
+import (
+	"errors"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/jimsnab/sqlrows-go"
+)
+
+type (
+	ParquetOption func(cfg *parquetConfig)
+
+	parquetConfig struct {
+		batchSize int
+	}
+)
+
+// Sets the number of rows per Parquet row group. The default is 65536.
+func BatchSize(rows int) ParquetOption {
+	return func(cfg *parquetConfig) {
+		cfg.batchSize = rows
+	}
+}
+
+// Writes the rows of [rs] to [w] as a Parquet file. The schema comes from the
+// column types: decimals keep their precision and scale, times are nanosecond
+// timestamps, and nullable columns are optional. [rs] is closed once drained.
+func WriteParquet(w io.Writer, rs sqlrows.RowSet, opts ...ParquetOption) error {
+	cfg := parquetConfig{batchSize: 65536}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	reader, err := newArrowReader(rs, memory.DefaultAllocator, cfg.batchSize)
+	if err != nil {
+		return errors.Join(err, rs.Close())
+	}
+	defer reader.Release()
+
+	props := parquet.NewWriterProperties(parquet.WithVersion(parquet.V2_LATEST))
+	pw, err := pqarrow.NewFileWriter(reader.Schema(), w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return errors.Join(err, rs.Close())
+	}
+
+	for reader.Next() {
+		if err = pw.Write(reader.RecordBatch()); err != nil {
+			return errors.Join(err, rs.Close())
+		}
+	}
+	if err = reader.Err(); err != nil {
+		return errors.Join(err, rs.Close())
+	}
+
+	return errors.Join(pw.Close(), rs.Close())
+}
@@ -0,0 +1,54 @@
+package arrowrows
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteParquet(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteParquet(&buf, newFixture(), BatchSize(1)))
+
+	tbl, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf.Bytes()), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer tbl.Release()
+
+	schema := tbl.Schema()
+	require.Equal(t, 5, len(schema.Fields()))
+	assert.Equal(t, arrow.PrimitiveTypes.Int64, schema.Field(0).Type)
+	assert.False(t, schema.Field(0).Nullable)
+	assert.Equal(t, arrow.BinaryTypes.String, schema.Field(1).Type)
+	assert.True(t, schema.Field(1).Nullable)
+	assert.Equal(t, &arrow.Decimal128Type{Precision: 10, Scale: 2}, schema.Field(2).Type)
+	assert.Equal(t, &arrow.TimestampType{Unit: arrow.Nanosecond}, schema.Field(3).Type)
+	assert.Equal(t, int64(2), tbl.NumRows())
+
+	reader := array.NewTableReader(tbl, 2)
+	defer reader.Release()
+	var prices []string
+	var created []any
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		dec := rec.Column(2).(*array.Decimal128)
+		ts := rec.Column(3).(*array.Timestamp)
+		for i := range int(rec.NumRows()) {
+			prices = append(prices, dec.Value(i).ToString(2))
+			if ts.IsNull(i) {
+				created = append(created, nil)
+			} else {
+				created = append(created, ts.Value(i).ToTime(arrow.Nanosecond))
+			}
+		}
+	}
+	assert.Equal(t, []string{"10.50", "3.00"}, prices)
+	assert.Equal(t, []any{time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC), nil}, created)
+}
@@ -0,0 +1,344 @@
+package arrowrows
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/jimsnab/sqlrows-go"
+)
+
+type (
+	// rowSetArrowReader reads a RowSet as a series of Arrow record batches
+	rowSetArrowReader struct {
+		refs      atomic.Int64
+		rs        sqlrows.RowSet
+		columns   []string
+		schema    *arrow.Schema
+		builder   *array.RecordBuilder
+		batchSize int
+		current   arrow.RecordBatch
+		done      bool
+		err       error
+	}
+)
+
+// field metadata keys that carry the SQL column type through Arrow
+const (
+	arrowMetaDbType    = "sqlrows.dbtype"
+	arrowMetaLength    = "sqlrows.length"
+	arrowMetaPrecision = "sqlrows.precision"
+	arrowMetaScale     = "sqlrows.scale"
+)
+
+// Reads [rs] as Arrow record batches of up to [batchSize] rows, allocated from
+// [mem], or the default allocator when nil. The schema comes from the column types:
+// DECIMAL/NUMBER columns with a scale become decimal128, timestamps with a time
+// zone become UTC nanosecond timestamps, and nullable columns become nullable
+// fields. The database type name, length, precision and scale are kept in the
+// field metadata. Release the reader when done; it does not close [rs].
+func NewReader(rs sqlrows.RowSet, batchSize int, mem memory.Allocator) (array.RecordReader, error) {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	return newArrowReader(rs, mem, batchSize)
+}
+
+func newArrowReader(rs sqlrows.RowSet, mem memory.Allocator, batchSize int) (*rowSetArrowReader, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", batchSize)
+	}
+
+	columns, err := rs.Columns()
+	if err != nil {
+		return nil, err
+	}
+	colTypes, err := rs.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(colTypes) != len(columns) {
+		return nil, fmt.Errorf("row set has %d columns but %d column types", len(columns), len(colTypes))
+	}
+
+	fields := make([]arrow.Field, len(columns))
+	for i, ct := range colTypes {
+		fields[i] = arrowField(columns[i], ct)
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	reader := &rowSetArrowReader{
+		rs:        rs,
+		columns:   columns,
+		schema:    schema,
+		builder:   array.NewRecordBuilder(mem, schema),
+		batchSize: batchSize,
+	}
+	reader.refs.Store(1)
+	return reader, nil
+}
+
+// arrowField describes a column as an Arrow field, keeping the SQL type in the metadata
+func arrowField(name string, ct sqlrows.ColumnType) arrow.Field {
+	keys := []string{arrowMetaDbType}
+	vals := []string{ct.DatabaseTypeName()}
+	if length, ok := ct.Length(); ok {
+		keys = append(keys, arrowMetaLength)
+		vals = append(vals, strconv.FormatInt(length, 10))
+	}
+	if precision, scale, ok := ct.DecimalSize(); ok {
+		keys = append(keys, arrowMetaPrecision, arrowMetaScale)
+		vals = append(vals, strconv.FormatInt(precision, 10), strconv.FormatInt(scale, 10))
+	}
+
+	nullable, ok := ct.Nullable()
+	return arrow.Field{
+		Name:     name,
+		Type:     arrowType(ct),
+		Nullable: nullable || !ok,
+		Metadata: arrow.NewMetadata(keys, vals),
+	}
+}
+
+// arrowType chooses the Arrow type for a column from its database type and Go scan type
+func arrowType(ct sqlrows.ColumnType) arrow.DataType {
+	dbName := baseDbTypeName(ct.DatabaseTypeName())
+	scanType := baseScanType(ct)
+
+	switch dbName {
+	case "NUMBER", "NUMERIC", "DECIMAL", "FIXED":
+		precision, scale, ok := ct.DecimalSize()
+		isInt := scanType != nil && isIntKind(scanType.Kind())
+		if ok && precision > 0 && precision <= 38 && (scale > 0 || !isInt) {
+			return &arrow.Decimal128Type{Precision: int32(precision), Scale: int32(scale)}
+		}
+	}
+
+	switch scanType {
+	case nil:
+		return arrow.BinaryTypes.String
+	case timeType:
+		if isZonedTimestamp(ct.DatabaseTypeName()) {
+			return &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+		}
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}
+	case bytesType:
+		return arrow.BinaryTypes.Binary
+	case uuidType:
+		return arrow.BinaryTypes.String
+	}
+
+	switch scanType.Kind() {
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32
+	case reflect.Int, reflect.Int64:
+		return arrow.PrimitiveTypes.Int64
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return arrow.PrimitiveTypes.Uint64
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64
+	}
+	return arrow.BinaryTypes.String
+}
+
+// isZonedTimestamp reports whether a timestamp type records an instant rather than a wall clock
+func isZonedTimestamp(dbTypeName string) bool {
+	name := strings.ToUpper(dbTypeName)
+	switch {
+	case strings.Contains(name, "WITHOUT TIME ZONE"):
+		return false
+	case strings.Contains(name, "TIME ZONE"), strings.Contains(name, "TIMESTAMPTZ"),
+		strings.Contains(name, "TIMESTAMP_TZ"), strings.Contains(name, "TIMESTAMP_LTZ"),
+		strings.Contains(name, "DATETIMEOFFSET"):
+		return true
+	}
+	return false
+}
+
+// appendArrowValue adds a value to a builder, converting it to the builder's type
+func appendArrowValue(b array.Builder, v any) error {
+	v = normalizeValue(v)
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch tb := b.(type) {
+	case *array.BooleanBuilder:
+		return appendAs(v, tb.Append)
+	case *array.Int8Builder:
+		return appendAs(v, tb.Append)
+	case *array.Int16Builder:
+		return appendAs(v, tb.Append)
+	case *array.Int32Builder:
+		return appendAs(v, tb.Append)
+	case *array.Int64Builder:
+		return appendAs(v, tb.Append)
+	case *array.Uint8Builder:
+		return appendAs(v, tb.Append)
+	case *array.Uint16Builder:
+		return appendAs(v, tb.Append)
+	case *array.Uint32Builder:
+		return appendAs(v, tb.Append)
+	case *array.Uint64Builder:
+		return appendAs(v, tb.Append)
+	case *array.Float32Builder:
+		return appendAs(v, tb.Append)
+	case *array.Float64Builder:
+		return appendAs(v, tb.Append)
+	case *array.StringBuilder:
+		return appendAs(v, tb.Append)
+	case *array.BinaryBuilder:
+		return appendAs(v, tb.Append)
+	case *array.Decimal128Builder:
+		dt := tb.Type().(*arrow.Decimal128Type)
+		var num decimal128.Num
+		var err error
+		if f, ok := v.(float64); ok {
+			num, err = decimal128.FromFloat64(f, dt.Precision, dt.Scale)
+		} else if f, ok := v.(float32); ok {
+			num, err = decimal128.FromFloat64(float64(f), dt.Precision, dt.Scale)
+		} else {
+			var text string
+			if err = sqlrows.ConvertAssign(&text, v); err != nil {
+				return err
+			}
+			num, err = decimal128.FromString(text, dt.Precision, dt.Scale)
+		}
+		if err != nil {
+			return err
+		}
+		tb.Append(num)
+		return nil
+	case *array.TimestampBuilder:
+		var t time.Time
+		if err := assignTime(&t, v); err != nil {
+			return err
+		}
+		if tb.Type().(*arrow.TimestampType).TimeZone == "" {
+			// wall clock time, stored as if it were UTC
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+		}
+		tb.Append(arrow.Timestamp(t.UnixNano()))
+		return nil
+	}
+
+	return fmt.Errorf("unsupported arrow builder %T", b)
+}
+
+func appendAs[T any](v any, appendFn func(T)) error {
+	var val T
+	if err := sqlrows.ConvertAssign(&val, v); err != nil {
+		return err
+	}
+	appendFn(val)
+	return nil
+}
+
+// assignTime stores a time value, parsing it if a driver delivered it as text
+func assignTime(dest *time.Time, v any) error {
+	switch tv := v.(type) {
+	case time.Time:
+		*dest = tv
+		return nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, tv)
+		if err != nil {
+			return err
+		}
+		*dest = t
+		return nil
+	}
+	return fmt.Errorf("cannot convert %T to a timestamp", v)
+}
+
+func (r *rowSetArrowReader) Retain() {
+	r.refs.Add(1)
+}
+
+func (r *rowSetArrowReader) Release() {
+	if r.refs.Add(-1) == 0 {
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+		r.builder.Release()
+	}
+}
+
+func (r *rowSetArrowReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *rowSetArrowReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	if r.done {
+		return false
+	}
+
+	count := 0
+	for count < r.batchSize {
+		if !r.rs.Next() {
+			r.err = r.rs.Err()
+			r.done = true
+			break
+		}
+		row, err := readRow(r.rs, len(r.columns))
+		if err != nil {
+			r.err = err
+			r.done = true
+			break
+		}
+		for i, v := range row {
+			if err := appendArrowValue(r.builder.Field(i), v); err != nil {
+				r.err = fmt.Errorf("column %s: %w", r.columns[i], err)
+				r.done = true
+				return false
+			}
+		}
+		count++
+	}
+
+	if r.err != nil || count == 0 {
+		return false
+	}
+	r.current = r.builder.NewRecordBatch()
+	return true
+}
+
+func (r *rowSetArrowReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+// Deprecated: Use RecordBatch instead.
+func (r *rowSetArrowReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *rowSetArrowReader) Err() error {
+	return r.err
+}
@@ -0,0 +1,102 @@
+package arrowrows
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/jimsnab/sqlrows-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFixture() sqlrows.MockRowSet {
+	rs := sqlrows.NewMockRowSet([]string{
+		"name=ID;type=int64",
+		"name=NAME;type=*string",
+		"name=PRICE;type=float64;precision=10;scale=2;dbType=NUMBER",
+		"name=CREATED;type=*time.Time;dbType=TIMESTAMP_NTZ",
+		"name=DATA;type=*string",
+	}, sqlrows.DbTypeSnowflake)
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	name := "Widget, large"
+	data := `{"a":1}`
+	rs.AddRow([]any{int64(1), &name, 10.5, &created, &data})
+	rs.AddRow([]any{int64(2), nil, 3.0, nil, nil})
+	return rs
+}
+
+// readAll drains [rs], returning each row as plain values or nil
+func readAll(t *testing.T, rs sqlrows.RowSet) [][]any {
+	t.Helper()
+	columns, err := rs.Columns()
+	require.NoError(t, err)
+
+	var rows [][]any
+	for rs.Next() {
+		row, err := readRow(rs, len(columns))
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+	require.NoError(t, rs.Err())
+	return rows
+}
+
+func TestNewReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	src := newFixture()
+	src.AddRow([]any{int64(3), nil, 1.25, nil, nil})
+	reader, err := NewReader(src, 2, mem)
+	require.NoError(t, err)
+	defer reader.Release()
+
+	schema := reader.Schema()
+	assert.Equal(t, &arrow.Decimal128Type{Precision: 10, Scale: 2}, schema.Field(2).Type)
+	assert.False(t, schema.Field(2).Nullable)
+	assert.True(t, schema.Field(3).Nullable)
+	dbType, _ := schema.Field(3).Metadata.GetValue("sqlrows.dbtype")
+	assert.Equal(t, "TIMESTAMP_NTZ", dbType)
+
+	var sizes []int64
+	for reader.Next() {
+		sizes = append(sizes, reader.RecordBatch().NumRows())
+	}
+	require.NoError(t, reader.Err())
+	assert.Equal(t, []int64{2, 1}, sizes)
+
+	_, err = NewReader(newFixture(), 0, nil)
+	assert.EqualError(t, err, "invalid batch size 0")
+}
+
+func TestRoundTrip(t *testing.T) {
+	reader, err := NewReader(newFixture(), 1, nil)
+	require.NoError(t, err)
+
+	rs := NewRowSet(reader)
+	defer rs.Close()
+
+	columns, err := rs.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "NAME", "PRICE", "CREATED", "DATA"}, columns)
+
+	colTypes, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	assert.Equal(t, "NUMBER", colTypes[2].DatabaseTypeName())
+	precision, scale, ok := colTypes[2].DecimalSize()
+	assert.Equal(t, []any{int64(10), int64(2), true}, []any{precision, scale, ok})
+	assert.Equal(t, reflect.TypeOf(int64(0)), colTypes[0].ScanType())
+	assert.Equal(t, reflect.TypeOf(new(time.Time)), colTypes[3].ScanType())
+	length, ok := colTypes[1].Length()
+	assert.Equal(t, int64(16777216), length)
+	assert.True(t, ok)
+
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	assert.Equal(t, [][]any{
+		{int64(1), "Widget, large", "10.50", created, `{"a":1}`},
+		{int64(2), nil, "3.00", nil, nil},
+	}, readAll(t, rs))
+}
@@ -0,0 +1,265 @@
+package arrowrows
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/jimsnab/sqlrows-go"
+)
+
+type (
+	// arrowRowSet exposes an Arrow record reader as a RowSet
+	arrowRowSet struct {
+		reader   array.RecordReader
+		columns  []string
+		colTypes []sqlrows.ColumnType
+		batch    arrow.RecordBatch
+		row      int
+		started  bool
+		done     bool
+		closed   bool
+	}
+
+	arrowColumnType struct {
+		field    arrow.Field
+		scanType reflect.Type
+		dbType   string
+	}
+)
+
+// Exposes an Arrow record reader as a RowSet, so Arrow data can feed code written
+// against RowSet. Decimals are delivered as text, as gosnowflake does, and scan
+// into a string or float64. Column types read back the metadata written by
+// NewReader when present. Closing the row set releases [reader].
+func NewRowSet(reader array.RecordReader) sqlrows.RowSet {
+	schema := reader.Schema()
+	rs := &arrowRowSet{
+		reader:   reader,
+		columns:  make([]string, 0, schema.NumFields()),
+		colTypes: make([]sqlrows.ColumnType, 0, schema.NumFields()),
+	}
+	for _, field := range schema.Fields() {
+		rs.columns = append(rs.columns, field.Name)
+		rs.colTypes = append(rs.colTypes, newArrowColumnType(field))
+	}
+	return rs
+}
+
+func newArrowColumnType(field arrow.Field) *arrowColumnType {
+	ct := &arrowColumnType{field: field}
+
+	var dbType string
+	switch dt := field.Type.(type) {
+	case *arrow.BooleanType:
+		ct.scanType, dbType = reflect.TypeOf(false), "BOOLEAN"
+	case *arrow.Int8Type:
+		ct.scanType, dbType = reflect.TypeOf(int8(0)), "TINYINT"
+	case *arrow.Int16Type:
+		ct.scanType, dbType = reflect.TypeOf(int16(0)), "SMALLINT"
+	case *arrow.Int32Type:
+		ct.scanType, dbType = reflect.TypeOf(int32(0)), "INTEGER"
+	case *arrow.Int64Type:
+		ct.scanType, dbType = reflect.TypeOf(int64(0)), "BIGINT"
+	case *arrow.Uint8Type:
+		ct.scanType, dbType = reflect.TypeOf(uint8(0)), "SMALLINT"
+	case *arrow.Uint16Type:
+		ct.scanType, dbType = reflect.TypeOf(uint16(0)), "INTEGER"
+	case *arrow.Uint32Type:
+		ct.scanType, dbType = reflect.TypeOf(uint32(0)), "BIGINT"
+	case *arrow.Uint64Type:
+		ct.scanType, dbType = reflect.TypeOf(uint64(0)), "NUMERIC"
+	case *arrow.Float32Type:
+		ct.scanType, dbType = reflect.TypeOf(float32(0)), "REAL"
+	case *arrow.Float64Type:
+		ct.scanType, dbType = reflect.TypeOf(float64(0)), "DOUBLE"
+	case *arrow.StringType, *arrow.LargeStringType:
+		ct.scanType, dbType = reflect.TypeOf(""), "VARCHAR"
+	case *arrow.BinaryType, *arrow.LargeBinaryType, *arrow.FixedSizeBinaryType:
+		ct.scanType, dbType = bytesType, "BINARY"
+	case *arrow.Decimal128Type:
+		ct.scanType, dbType = reflect.TypeOf(""), "DECIMAL"
+	case *arrow.TimestampType:
+		ct.scanType, dbType = timeType, "TIMESTAMP"
+		if dt.TimeZone != "" {
+			dbType = "TIMESTAMP WITH TIME ZONE"
+		}
+	case *arrow.Date32Type, *arrow.Date64Type:
+		ct.scanType, dbType = timeType, "DATE"
+	default:
+		ct.scanType, dbType = reflect.TypeOf(""), strings.ToUpper(field.Type.Name())
+	}
+
+	ct.dbType = dbType
+	if name, ok := field.Metadata.GetValue(arrowMetaDbType); ok && name != "" {
+		ct.dbType = name
+	}
+	if field.Nullable {
+		ct.scanType = reflect.PointerTo(ct.scanType)
+	}
+	return ct
+}
+
+func (ct *arrowColumnType) DatabaseTypeName() string {
+	return ct.dbType
+}
+
+func (ct *arrowColumnType) DecimalSize() (precision int64, scale int64, ok bool) {
+	if dt, isDecimal := ct.field.Type.(*arrow.Decimal128Type); isDecimal {
+		return int64(dt.Precision), int64(dt.Scale), true
+	}
+	precision, hasPrecision := ct.metadataInt(arrowMetaPrecision)
+	scale, hasScale := ct.metadataInt(arrowMetaScale)
+	return precision, scale, hasPrecision && hasScale
+}
+
+func (ct *arrowColumnType) Length() (length int64, ok bool) {
+	return ct.metadataInt(arrowMetaLength)
+}
+
+func (ct *arrowColumnType) Name() string {
+	return ct.field.Name
+}
+
+func (ct *arrowColumnType) Nullable() (nullable bool, ok bool) {
+	return ct.field.Nullable, true
+}
+
+func (ct *arrowColumnType) ScanType() reflect.Type {
+	return ct.scanType
+}
+
+func (ct *arrowColumnType) metadataInt(key string) (int64, bool) {
+	text, found := ct.field.Metadata.GetValue(key)
+	if !found {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	return n, err == nil
+}
+
+// arrowValue extracts a value from an Arrow array as the Go type a driver would deliver
+func arrowValue(arr arrow.Array, i int) any {
+	if arr.IsNull(i) {
+		return nil
+	}
+
+	switch ta := arr.(type) {
+	case *array.Boolean:
+		return ta.Value(i)
+	case *array.Int8:
+		return ta.Value(i)
+	case *array.Int16:
+		return ta.Value(i)
+	case *array.Int32:
+		return ta.Value(i)
+	case *array.Int64:
+		return ta.Value(i)
+	case *array.Uint8:
+		return ta.Value(i)
+	case *array.Uint16:
+		return ta.Value(i)
+	case *array.Uint32:
+		return ta.Value(i)
+	case *array.Uint64:
+		return ta.Value(i)
+	case *array.Float32:
+		return ta.Value(i)
+	case *array.Float64:
+		return ta.Value(i)
+	case *array.String:
+		return ta.Value(i)
+	case *array.LargeString:
+		return ta.Value(i)
+	case *array.Binary:
+		return bytes.Clone(ta.Value(i))
+	case *array.LargeBinary:
+		return bytes.Clone(ta.Value(i))
+	case *array.FixedSizeBinary:
+		return bytes.Clone(ta.Value(i))
+	case *array.Decimal128:
+		return ta.Value(i).ToString(ta.DataType().(*arrow.Decimal128Type).Scale)
+	case *array.Timestamp:
+		dt := ta.DataType().(*arrow.TimestampType)
+		return ta.Value(i).ToTime(dt.Unit)
+	case *array.Date32:
+		return ta.Value(i).ToTime()
+	case *array.Date64:
+		return ta.Value(i).ToTime()
+	}
+	return arr.ValueStr(i)
+}
+
+func (rs *arrowRowSet) Close() error {
+	if rs.closed {
+		return nil
+	}
+	rs.closed = true
+	rs.done = true
+	rs.batch = nil
+	rs.reader.Release()
+	return nil
+}
+
+func (rs *arrowRowSet) ColumnTypes() ([]sqlrows.ColumnType, error) {
+	return rs.colTypes, nil
+}
+
+func (rs *arrowRowSet) Columns() ([]string, error) {
+	return rs.columns, nil
+}
+
+func (rs *arrowRowSet) Err() error {
+	if rs.closed {
+		return nil
+	}
+	return rs.reader.Err()
+}
+
+func (rs *arrowRowSet) Next() bool {
+	rs.started = true
+	if rs.done {
+		return false
+	}
+
+	rs.row++
+	for rs.batch == nil || rs.row >= int(rs.batch.NumRows()) {
+		if !rs.reader.Next() {
+			rs.batch = nil
+			rs.done = true
+			return false
+		}
+		rs.batch = rs.reader.RecordBatch()
+		rs.row = 0
+	}
+	return true
+}
+
+func (rs *arrowRowSet) NextResultSet() bool {
+	return false
+}
+
+func (rs *arrowRowSet) Scan(dest ...any) error {
+	if !rs.started {
+		return errors.New("sql: Scan called without calling Next")
+	}
+	if rs.batch == nil {
+		return fmt.Errorf("no more rows")
+	}
+
+	n := int(rs.batch.NumCols())
+	if len(dest) != n {
+		return fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", n, len(dest))
+	}
+	for i := range n {
+		if err := sqlrows.ConvertAssign(dest[i], arrowValue(rs.batch.Column(i), rs.row)); err != nil {
+			return fmt.Errorf("sql: Scan error on column index %d, name %q: %w", i, rs.columns[i], err)
+		}
+	}
+	return nil
+}
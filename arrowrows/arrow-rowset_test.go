@@ -0,0 +1,47 @@
+package arrowrows
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowSetScan(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "amount", Type: &arrow.Decimal128Type{Precision: 12, Scale: 3}, Nullable: true},
+	}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int32Builder).AppendValues([]int32{7, 8}, nil)
+	require.NoError(t, builder.Field(1).(*array.Decimal128Builder).AppendValueFromString("12.345"))
+	builder.Field(1).AppendNull()
+	batch := builder.NewRecordBatch()
+	defer batch.Release()
+
+	reader, err := array.NewRecordReader(schema, []arrow.RecordBatch{batch})
+	require.NoError(t, err)
+	rs := NewRowSet(reader)
+
+	var id int
+	var amount *float64
+	assert.EqualError(t, rs.Scan(&id, &amount), "sql: Scan called without calling Next")
+	require.True(t, rs.Next())
+	require.NoError(t, rs.Scan(&id, &amount))
+	assert.Equal(t, 7, id)
+	require.NotNil(t, amount)
+	assert.Equal(t, 12.345, *amount)
+
+	require.True(t, rs.Next())
+	require.NoError(t, rs.Scan(&id, &amount))
+	assert.Equal(t, 8, id)
+	assert.Nil(t, amount)
+
+	assert.False(t, rs.Next())
+	assert.NoError(t, rs.Err())
+	assert.NoError(t, rs.Close())
+}
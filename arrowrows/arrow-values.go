@@ -0,0 +1,75 @@
+package arrowrows
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jimsnab/sqlrows-go"
+)
+
+var (
+	bytesType = reflect.TypeOf([]byte(nil))
+	timeType  = reflect.TypeOf(time.Time{})
+	uuidType  = reflect.TypeOf(uuid.UUID{})
+)
+
+// readRow scans the current row of [rs] into a slice of [n] plain values or nil
+func readRow(rs sqlrows.RowSet, n int) ([]any, error) {
+	holders := make([]any, n)
+	dest := make([]any, n)
+	for i := range dest {
+		dest[i] = &holders[i]
+	}
+
+	if err := rs.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	vals := make([]any, n)
+	for i, v := range holders {
+		vals[i] = normalizeValue(v)
+	}
+	return vals, nil
+}
+
+// normalizeValue dereferences pointer values, turning nil pointers into nil
+func normalizeValue(v any) any {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface()
+}
+
+func baseScanType(ct sqlrows.ColumnType) reflect.Type {
+	t := ct.ScanType()
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// baseDbTypeName strips parameters from a type name, so NUMBER(10,2) becomes NUMBER
+func baseDbTypeName(name string) string {
+	if index := strings.IndexByte(name, '('); index >= 0 {
+		name = name[:index]
+	}
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	sqlrows "github.com/jimsnab/sqlrows-go"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// schemaFile is the YAML input: one or more named queries
+	schemaFile struct {
+		Package string  `yaml:"package"`
+		Queries []query `yaml:"queries"`
+	}
+
+	// query names a result shape and lists its columns in the NewMockRowSet syntax
+	query struct {
+		Name    string   `yaml:"name"`
+		DbType  string   `yaml:"dbType"`
+		Columns []string `yaml:"columns"`
+	}
+
+	genQuery struct {
+		Name    string
+		VarName string
+		DbConst string
+		Columns []string
+		Fields  []genField
+	}
+
+	genField struct {
+		Name   string
+		GoType string
+	}
+)
+
+var dbTypeNames = map[string]string{
+	"snowflake":  "DbTypeSnowflake",
+	"postgres":   "DbTypePostgresSQL",
+	"postgresql": "DbTypePostgresSQL",
+	"mssql":      "DbTypeMsSQL",
+	"sqlserver":  "DbTypeMsSQL",
+}
+
+var dbTypeValues = map[string]sqlrows.DatabaseType{
+	"DbTypeSnowflake":   sqlrows.DbTypeSnowflake,
+	"DbTypePostgresSQL": sqlrows.DbTypePostgresSQL,
+	"DbTypeMsSQL":       sqlrows.DbTypeMsSQL,
+}
+
+// import paths of the packages that qualify mock column types
+var importPaths = map[string]string{
+	"time": "time",
+	"uuid": "github.com/google/uuid",
+	"json": "encoding/json",
+}
+
+// common initialisms kept upper case in field names
+var initialisms = map[string]struct{}{
+	"API": {}, "HTTP": {}, "ID": {}, "IP": {}, "JSON": {}, "SQL": {}, "URL": {}, "UUID": {},
+}
+
+var sourceTemplate = template.Must(template.New("source").Parse(`// Code generated by sqlrows-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+{{if .StdImports}}
+{{end -}}
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+	sqlrows "github.com/jimsnab/sqlrows-go"
+)
+{{range .Queries}}
+// {{.Name}} is one row of the {{.Name}} query result
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.GoType}}
+{{- end}}
+}
+
+var {{.VarName}}Columns = []string{
+{{- range .Columns}}
+	{{printf "%q" .}},
+{{- end}}
+}
+
+// Scan{{.Name}} reads the remaining rows of [rs]. [rs] is not closed.
+func Scan{{.Name}}(rs sqlrows.RowSet) ([]{{.Name}}, error) {
+	var rows []{{.Name}}
+	for rs.Next() {
+		var row {{.Name}}
+		if err := rs.Scan(
+		{{- range .Fields}}
+			&row.{{.Name}},
+		{{- end}}
+		); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, rs.Err()
+}
+
+// NewMock{{.Name}} returns a mock row set with the {{.Name}} columns holding [rows]
+func NewMock{{.Name}}(rows ...{{.Name}}) sqlrows.MockRowSet {
+	rs := sqlrows.NewMockRowSet({{.VarName}}Columns, sqlrows.{{.DbConst}})
+	for _, row := range rows {
+		rs.AddRow([]any{
+		{{- range .Fields}}
+			row.{{.Name}},
+		{{- end}}
+		})
+	}
+	return rs
+}
+{{end}}`))
+
+// loadSchema reads a YAML file of queries, or a text file of column specs, one
+// per line, for the query given by [name] and [dbType]
+func loadSchema(path, name, dbType string) (schemaFile, error) {
+	var schema schemaFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schema, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err = yaml.Unmarshal(data, &schema); err != nil {
+			return schema, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if name != "" {
+			schema.Queries = filterQueries(schema.Queries, name)
+			if len(schema.Queries) == 0 {
+				return schema, fmt.Errorf("query %s is not in %s", name, path)
+			}
+		}
+	default:
+		if name == "" || dbType == "" {
+			return schema, errors.New("-name and -db are required for a column spec file")
+		}
+		q := query{Name: name, DbType: dbType}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				q.Columns = append(q.Columns, line)
+			}
+		}
+		schema.Queries = []query{q}
+	}
+
+	if len(schema.Queries) == 0 {
+		return schema, fmt.Errorf("no queries in %s", path)
+	}
+	return schema, nil
+}
+
+func filterQueries(queries []query, name string) []query {
+	var selected []query
+	for _, q := range queries {
+		if q.Name == name {
+			selected = append(selected, q)
+		}
+	}
+	return selected
+}
+
+// generate renders the struct, scanner and mock constructor of each query as
+// gofmt'd source
+func generate(pkg string, queries []query) ([]byte, error) {
+	imports := map[string]struct{}{}
+	var genQueries []genQuery
+	for _, q := range queries {
+		gq, err := prepareQuery(q, imports)
+		if err != nil {
+			return nil, err
+		}
+		genQueries = append(genQueries, gq)
+	}
+
+	// standard library paths have no dot in the first element
+	var stdImports, importList []string
+	for path := range imports {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			importList = append(importList, path)
+		} else {
+			stdImports = append(stdImports, path)
+		}
+	}
+	sort.Strings(stdImports)
+	sort.Strings(importList)
+
+	var buf bytes.Buffer
+	err := sourceTemplate.Execute(&buf, map[string]any{
+		"Package":    pkg,
+		"StdImports": stdImports,
+		"Imports":    importList,
+		"Queries":    genQueries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+	return source, nil
+}
+
+// prepareQuery validates the column specs with the mock parser, and picks the
+// field names and Go types
+func prepareQuery(q query, imports map[string]struct{}) (gq genQuery, err error) {
+	if !isExported(q.Name) {
+		return gq, fmt.Errorf("query name %q must be an exported Go identifier", q.Name)
+	}
+	dbConst, found := dbTypeNames[strings.ToLower(q.DbType)]
+	if !found {
+		return gq, fmt.Errorf("query %s: unknown database type %q", q.Name, q.DbType)
+	}
+	if len(q.Columns) == 0 {
+		return gq, fmt.Errorf("query %s has no columns", q.Name)
+	}
+
+	colTypes, err := mockColumnTypes(q.Columns, dbTypeValues[dbConst])
+	if err != nil {
+		return gq, fmt.Errorf("query %s: %w", q.Name, err)
+	}
+
+	gq = genQuery{
+		Name:    q.Name,
+		VarName: strings.ToLower(q.Name[:1]) + q.Name[1:],
+		DbConst: dbConst,
+		Columns: q.Columns,
+	}
+	seen := map[string]string{}
+	for i, ct := range colTypes {
+		field := fieldName(ct.Name())
+		if other, dup := seen[field]; dup {
+			return gq, fmt.Errorf("query %s: columns %s and %s both map to field %s", q.Name, other, ct.Name(), field)
+		}
+		seen[field] = ct.Name()
+
+		// the spec's type name is kept, since reflect names some types by
+		// what they alias, such as []uint8 for []byte
+		goType := specType(q.Columns[i])
+		qualified := strings.TrimLeft(goType, "*[]")
+		if pkg, _, found := strings.Cut(qualified, "."); found {
+			path, known := importPaths[pkg]
+			if !known {
+				return gq, fmt.Errorf("query %s: no import path for type %s", q.Name, goType)
+			}
+			imports[path] = struct{}{}
+		}
+		gq.Fields = append(gq.Fields, genField{Name: field, GoType: goType})
+	}
+	return gq, nil
+}
+
+// specType returns the value of a column spec's type key
+func specType(spec string) string {
+	for part := range strings.SplitSeq(spec, ";") {
+		if key, value, found := strings.Cut(part, "="); found && strings.TrimSpace(key) == "type" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// mockColumnTypes parses the specs by building an empty mock row set, turning
+// the parser's panic into an error
+func mockColumnTypes(specs []string, dbType sqlrows.DatabaseType) (colTypes []sqlrows.ColumnType, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return sqlrows.NewMockRowSet(specs, dbType).ColumnTypes()
+}
+
+// fieldName turns a column name such as ORDER_ID or orderId into an exported
+// Go identifier such as OrderID
+func fieldName(column string) string {
+	words := strings.FieldsFunc(column, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var sb strings.Builder
+	for _, word := range words {
+		// keep camel case words as written, but fold all-caps words
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		if _, found := initialisms[strings.ToUpper(word)]; found {
+			sb.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	name := sb.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "C" + name
+	}
+	return name
+}
+
+func isExported(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return unicode.IsUpper([]rune(name)[0])
+}
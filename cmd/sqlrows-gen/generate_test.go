@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedExampleIsCurrent(t *testing.T) {
+	schema, err := loadSchema("internal/example/orders.yaml", "", "")
+	require.NoError(t, err)
+	source, err := generate("example", schema.Queries)
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile("internal/example/orders_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(committed), string(source), "run go generate in internal/example")
+}
+
+func TestRunSpecFile(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "tenant.specs")
+	require.NoError(t, os.WriteFile(in, []byte("# tenant lookup\nname=TENANT_ID;type=int32\n\nname=display_name;type=*string\n"), 0o644))
+
+	require.NoError(t, run(in, "", "tenants", "Tenant", "postgres"))
+	source, err := os.ReadFile(filepath.Join(dir, "tenant_gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(source), ""+
+		"import (\n"+
+		"\tsqlrows \"github.com/jimsnab/sqlrows-go\"\n"+
+		")\n")
+	assert.Contains(t, string(source), ""+
+		"type Tenant struct {\n"+
+		"\tTenantID    int32\n"+
+		"\tDisplayName *string\n"+
+		"}\n")
+	assert.Contains(t, string(source), "rs := sqlrows.NewMockRowSet(tenantColumns, sqlrows.DbTypePostgresSQL)")
+
+	assert.EqualError(t, run(in, "", "tenants", "", "postgres"), "-name and -db are required for a column spec file")
+	assert.EqualError(t, run(in, "", "", "Tenant", "postgres"), "-package is required outside of go generate")
+}
+
+func TestGenerateImports(t *testing.T) {
+	source, err := generate("events", []query{{
+		Name:   "Event",
+		DbType: "postgres",
+		Columns: []string{
+			"name=id;type=uuid.UUID",
+			"name=payload;type=json.RawMessage",
+			"name=tags;type=*[]string",
+			"name=at;type=time.Time",
+		},
+	}})
+	require.NoError(t, err)
+	assert.Contains(t, string(source), ""+
+		"import (\n"+
+		"\t\"encoding/json\"\n"+
+		"\t\"time\"\n"+
+		"\n"+
+		"\t\"github.com/google/uuid\"\n"+
+		"\tsqlrows \"github.com/jimsnab/sqlrows-go\"\n"+
+		")\n")
+	assert.Contains(t, string(source), "\tTags    *[]string\n")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	cases := []struct {
+		q   query
+		err string
+	}{
+		{query{Name: "order", DbType: "snowflake", Columns: []string{"name=ID;type=int64"}}, `query name "order" must be an exported Go identifier`},
+		{query{Name: "Order", DbType: "oracle", Columns: []string{"name=ID;type=int64"}}, `query Order: unknown database type "oracle"`},
+		{query{Name: "Order", DbType: "snowflake"}, "query Order has no columns"},
+		{query{Name: "Order", DbType: "snowflake", Columns: []string{"name=ID;type=int128"}}, "query Order: unsupported type: int128"},
+		{query{Name: "Order", DbType: "mssql", Columns: []string{"name=ORDER_ID;type=int64", "name=order-id;type=int64"}}, "query Order: columns ORDER_ID and order-id both map to field OrderID"},
+	}
+	for _, c := range cases {
+		_, err := generate("example", []query{c.q})
+		assert.EqualError(t, err, c.err)
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	for column, field := range map[string]string{
+		"ORDER_ID":      "OrderID",
+		"orderId":       "OrderId",
+		"customer url":  "CustomerURL",
+		"2FA_ENABLED":   "C2faEnabled",
+		"Amount":        "Amount",
+		"__json_blob__": "JSONBlob",
+	} {
+		assert.Equal(t, field, fieldName(column), column)
+	}
+}
@@ -0,0 +1,20 @@
+module github.com/jimsnab/sqlrows-go/cmd/sqlrows-gen
+
+go 1.24.0
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/jimsnab/sqlrows-go v0.0.0-20261018141233-4d4d86a8a31c
+	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
+
+// Builds inside this repository use the root directory; the require above
+// names the published root module commit that users of sqlrows-gen resolve,
+// since replace directives of dependencies are ignored.
+replace github.com/jimsnab/sqlrows-go => ../..
@@ -0,0 +1,5 @@
+// Package example holds sqlrows-gen output for the orders.yaml schema, which the
+// generator tests compile and keep in sync.
+package example
+
+//go:generate go run ../.. -in orders.yaml
@@ -0,0 +1,27 @@
+package example
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedRoundTrip(t *testing.T) {
+	note := "leave at door"
+	shipped := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	orders := []Order{
+		{OrderID: 1, CustomerUUID: uuid.New(), Amount: 12.5, Note: &note, ShippedAt: &shipped},
+		{OrderID: 2, CustomerUUID: uuid.New(), Amount: 3},
+	}
+
+	scanned, err := ScanOrder(NewMockOrder(orders...))
+	require.NoError(t, err)
+	assert.Equal(t, orders, scanned)
+
+	tenants, err := ScanTenant(NewMockTenant())
+	require.NoError(t, err)
+	assert.Empty(t, tenants)
+}
@@ -0,0 +1,96 @@
+// Code generated by sqlrows-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	sqlrows "github.com/jimsnab/sqlrows-go"
+)
+
+// Order is one row of the Order query result
+type Order struct {
+	OrderID      int64
+	CustomerUUID uuid.UUID
+	Amount       float64
+	Note         *string
+	ShippedAt    *time.Time
+}
+
+var orderColumns = []string{
+	"name=ORDER_ID;type=int64",
+	"name=CUSTOMER_UUID;type=uuid.UUID",
+	"name=AMOUNT;type=float64;dbType=NUMBER;precision=12;scale=2",
+	"name=NOTE;type=*string;length=200",
+	"name=SHIPPED_AT;type=*time.Time",
+}
+
+// ScanOrder reads the remaining rows of [rs]. [rs] is not closed.
+func ScanOrder(rs sqlrows.RowSet) ([]Order, error) {
+	var rows []Order
+	for rs.Next() {
+		var row Order
+		if err := rs.Scan(
+			&row.OrderID,
+			&row.CustomerUUID,
+			&row.Amount,
+			&row.Note,
+			&row.ShippedAt,
+		); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, rs.Err()
+}
+
+// NewMockOrder returns a mock row set with the Order columns holding [rows]
+func NewMockOrder(rows ...Order) sqlrows.MockRowSet {
+	rs := sqlrows.NewMockRowSet(orderColumns, sqlrows.DbTypeSnowflake)
+	for _, row := range rows {
+		rs.AddRow([]any{
+			row.OrderID,
+			row.CustomerUUID,
+			row.Amount,
+			row.Note,
+			row.ShippedAt,
+		})
+	}
+	return rs
+}
+
+// Tenant is one row of the Tenant query result
+type Tenant struct {
+	Name string
+}
+
+var tenantColumns = []string{
+	"name=NAME;type=string",
+}
+
+// ScanTenant reads the remaining rows of [rs]. [rs] is not closed.
+func ScanTenant(rs sqlrows.RowSet) ([]Tenant, error) {
+	var rows []Tenant
+	for rs.Next() {
+		var row Tenant
+		if err := rs.Scan(
+			&row.Name,
+		); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, rs.Err()
+}
+
+// NewMockTenant returns a mock row set with the Tenant columns holding [rows]
+func NewMockTenant(rows ...Tenant) sqlrows.MockRowSet {
+	rs := sqlrows.NewMockRowSet(tenantColumns, sqlrows.DbTypeSnowflake)
+	for _, row := range rows {
+		rs.AddRow([]any{
+			row.Name,
+		})
+	}
+	return rs
+}
@@ -0,0 +1,70 @@
+// Command sqlrows-gen generates typed row structs, scanners and mock row set
+// constructors from column specs, so production code and tests share one
+// schema definition. It is its own module, added with
+// go get -tool github.com/jimsnab/sqlrows-go/cmd/sqlrows-gen, and is meant to
+// be run by go generate:
+//
+//	//go:generate go tool sqlrows-gen -in orders.yaml
+//
+// The input is a YAML file of named queries:
+//
+//	queries:
+//	  - name: Order
+//	    dbType: snowflake
+//	    columns:
+//	      - name=ID;type=int64
+//	      - name=SHIPPED;type=*time.Time
+//
+// or a text file with one column spec per line, given with -name and -db. For a
+// query named Order, the output declares the Order struct, ScanOrder and
+// NewMockOrder.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	in := flag.String("in", "", "YAML or column spec file to read (required)")
+	out := flag.String("out", "", "Go file to write; defaults to the input name with a _gen.go suffix")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file; defaults to $GOPACKAGE")
+	name := flag.String("name", "", "query to generate; required for a column spec file")
+	dbType := flag.String("db", "", "snowflake, postgres or mssql; required for a column spec file")
+	flag.Parse()
+
+	if err := run(*in, *out, *pkg, *name, *dbType); err != nil {
+		fmt.Fprintln(os.Stderr, "sqlrows-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pkg, name, dbType string) error {
+	if in == "" {
+		return fmt.Errorf("-in is required")
+	}
+
+	schema, err := loadSchema(in, name, dbType)
+	if err != nil {
+		return err
+	}
+	if pkg == "" {
+		pkg = schema.Package
+	}
+	if pkg == "" {
+		return fmt.Errorf("-package is required outside of go generate")
+	}
+
+	source, err := generate(pkg, schema.Queries)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		out = strings.TrimSuffix(in, filepath.Ext(in)) + "_gen.go"
+	}
+	return os.WriteFile(out, source, 0o644)
+}
@@ -0,0 +1,12 @@
+// Package sqlrows wraps *sql.Rows in the RowSet interface so that code which
+// reads query results can be tested against mock row sets.
+//
+// A mock row set hands back the values a test put in, converted to the scan
+// destinations with the database/sql rules. A column whose spec declares a time
+// dbType also gets that type's storage semantics: fractional seconds are cut to
+// the column's precision and zones are kept, normalized or dropped per dialect,
+// so time handling bugs reproduce. Columns without a declared dbType keep a
+// time.Time exactly as given. The rest of what a driver does to a value on the
+// way out, such as its decimal and UUID representations, is opt-in through
+// EmulateDriver.
+package sqlrows
@@ -3,9 +3,12 @@ package sqlrows
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type (
@@ -13,6 +16,12 @@ type (
 		RowSet
 		Add(row map[string]any)
 		AddRow(values []any)
+		AddColumn(colSpec string, defaultValue any)
+		DropColumn(name string)
+		RenameColumn(oldName, newName string)
+		ReorderColumns(names ...string)
+		Rewind()
+		String() string
 	}
 
 	DatabaseType int
@@ -23,9 +32,17 @@ type (
 		columns     []string
 		columnTypes []*mockColumnType
 		values      [][]any
+		dbType      DatabaseType
+		cfg         mockConfig
 		pos         int
 		err         error
+		closeMu     sync.Mutex // Close may be called from another goroutine, as WithContext does
+		closed      bool
+		stop        chan struct{}
 		hasNextSet  bool
+		generate    func(i int) []any
+		count       int
+		current     []any
 	}
 
 	mockColumnType struct {
@@ -35,7 +52,35 @@ type (
 		length       int64
 		precision    int64
 		scale        int64
+		hasScale     bool // the spec gave a scale, which may be zero
+		declaredType bool // the spec gave a dbType, whose time semantics apply
 		databaseType string
+		dbType       DatabaseType
+		noDialect    bool            // copied from a driver whose database is not known
+		copiedMeta   *columnMetadata // metadata copied from a driver, reported as is
+	}
+
+	// columnMetadata is what a driver reported for a column, including whether
+	// it reported each value at all
+	columnMetadata struct {
+		length         int64
+		hasLength      bool
+		precision      int64
+		scale          int64
+		hasDecimalSize bool
+		nullable       bool
+		hasNullable    bool
+	}
+
+	// columnSpec holds the keys of a column spec such as "name=ID;type=int64";
+	// the pointer fields are nil when the key was not given
+	columnSpec struct {
+		name      string
+		typeName  string
+		dbType    *string
+		length    *int64
+		precision *int64
+		scale     *int64
 	}
 )
 
@@ -47,6 +92,8 @@ const (
 
 var onPanic = func(errMsg string) { panic(errMsg) }
 
+var errRowsClosed = errors.New("sql: Rows are closed")
+
 // Creates a mock table, where [cols] is semicolon-separated list of <keyword>=<value>,
 // where <keyword> choices are:
 //
@@ -62,10 +109,14 @@ var onPanic = func(errMsg string) { panic(errMsg) }
 //		"name=UPDATE_TS;type=*time.Time"
 //	    "name=KEY;type=uuid.UUID"
 //	    "name=NAME;type=string;length=64"
-func NewMockRowSet(cols []string, dbType DatabaseType) MockRowSet {
+func NewMockRowSet(cols []string, dbType DatabaseType, opts ...MockOption) MockRowSet {
 	row := mockRowSet{
 		order:    map[string]struct{}{},
 		orderLwr: map[string]int{},
+		dbType:   dbType,
+	}
+	for _, opt := range opts {
+		opt(&row.cfg)
 	}
 
 	for _, colSpec := range cols {
@@ -95,16 +146,171 @@ func (set *mockRowSet) AddRow(values []any) {
 	set.values = append(set.values, vals)
 }
 
+// Adds a column to the mock table after rows may already exist. [colSpec] uses
+// the same syntax as NewMockRowSet, and [defaultValue] is stored in the new
+// column of every existing row.
+func (set *mockRowSet) AddColumn(colSpec string, defaultValue any) {
+	index := len(set.columns)
+	parseColumnSpec(colSpec, set.dbType, set)
+	if len(set.columns) == index {
+		return
+	}
+
+	for _, vals := range set.values {
+		vals[index] = defaultValue
+	}
+}
+
+// Removes a column, along with its values, from the mock table.
+func (set *mockRowSet) DropColumn(name string) {
+	index, valid := set.orderLwr[strings.ToLower(name)]
+	if !valid {
+		onPanic(fmt.Sprintf("column %s does not exist", name))
+		return
+	}
+
+	set.columns = append(set.columns[:index:index], set.columns[index+1:]...)
+	set.columnTypes = append(set.columnTypes[:index:index], set.columnTypes[index+1:]...)
+	for i, vals := range set.values {
+		set.values[i] = append(vals[:index:index], vals[index+1:]...)
+	}
+	set.reindex()
+}
+
+// Changes the name of a column in the mock table. Rows are unaffected.
+func (set *mockRowSet) RenameColumn(oldName, newName string) {
+	index, valid := set.orderLwr[strings.ToLower(oldName)]
+	if !valid {
+		onPanic(fmt.Sprintf("column %s does not exist", oldName))
+		return
+	}
+	if newName == "" {
+		onPanic("new column name is required")
+		return
+	}
+	if other, exists := set.orderLwr[strings.ToLower(newName)]; exists && other != index {
+		onPanic(fmt.Sprintf("duplicate column name in mock row set: %s", newName))
+		return
+	}
+
+	// column types may have been handed out by ColumnTypes(), so replace rather than modify
+	colType := *set.columnTypes[index]
+	colType.colName = newName
+
+	set.columns = append([]string{}, set.columns...)
+	set.columns[index] = newName
+	set.columnTypes[index] = &colType
+	set.reindex()
+}
+
+// Rearranges the columns of the mock table. [names] must list every column
+// exactly once, in the new order.
+func (set *mockRowSet) ReorderColumns(names ...string) {
+	if len(names) != len(set.columns) {
+		onPanic(fmt.Sprintf("reorder requires all %d columns, got %d", len(set.columns), len(names)))
+		return
+	}
+
+	mapping := make([]int, 0, len(names))
+	seen := map[int]struct{}{}
+	for _, name := range names {
+		index, valid := set.orderLwr[strings.ToLower(name)]
+		if !valid {
+			onPanic(fmt.Sprintf("column %s does not exist", name))
+			return
+		}
+		if _, dup := seen[index]; dup {
+			onPanic(fmt.Sprintf("column %s listed more than once", name))
+			return
+		}
+		seen[index] = struct{}{}
+		mapping = append(mapping, index)
+	}
+
+	columns := make([]string, len(mapping))
+	columnTypes := make([]*mockColumnType, len(mapping))
+	for to, from := range mapping {
+		columns[to] = set.columns[from]
+		columnTypes[to] = set.columnTypes[from]
+	}
+	for i, vals := range set.values {
+		newRow := make([]any, len(mapping))
+		for to, from := range mapping {
+			newRow[to] = vals[from]
+		}
+		set.values[i] = newRow
+	}
+
+	set.columns = columns
+	set.columnTypes = columnTypes
+	set.reindex()
+}
+
+// Moves the cursor back before the first row and reopens the mock if it was
+// closed or failed, so the rows can be read again.
+func (set *mockRowSet) Rewind() {
+	set.pos = 0
+	set.err = nil
+	set.closeMu.Lock()
+	set.closed = false
+	set.stop = nil
+	set.closeMu.Unlock()
+	set.cfg.latency.reset()
+}
+
+// clone copies the mock, rows included, so the copy can be read and changed
+// without affecting the original
+func (set *mockRowSet) clone() *mockRowSet {
+	c := &mockRowSet{
+		order:       maps.Clone(set.order),
+		orderLwr:    maps.Clone(set.orderLwr),
+		columns:     slices.Clone(set.columns),
+		columnTypes: slices.Clone(set.columnTypes),
+		values:      make([][]any, 0, len(set.values)),
+		dbType:      set.dbType,
+		cfg:         set.cfg,
+		pos:         set.pos,
+		err:         set.err,
+		closed:      set.isClosed(),
+		hasNextSet:  set.hasNextSet,
+		generate:    set.generate,
+		count:       set.count,
+		current:     slices.Clone(set.current),
+	}
+	for _, vals := range set.values {
+		c.values = append(c.values, slices.Clone(vals))
+	}
+	// the copy draws its own jitter sequence
+	c.cfg.latency.reset()
+	return c
+}
+
+// reindex rebuilds the column name lookups after the column list changes
+func (set *mockRowSet) reindex() {
+	set.order = make(map[string]struct{}, len(set.columns))
+	set.orderLwr = make(map[string]int, len(set.columns))
+	for i, name := range set.columns {
+		set.order[name] = struct{}{}
+		set.orderLwr[strings.ToLower(name)] = i
+	}
+}
+
 func (m *mockColumnType) DatabaseTypeName() string {
 	return m.databaseType
 }
 
 func (m *mockColumnType) DecimalSize() (precision int64, scale int64, ok bool) {
-	return m.precision, m.scale, m.precision != 0 || m.scale != 0
+	if m.copiedMeta != nil {
+		return m.copiedMeta.precision, m.copiedMeta.scale, m.copiedMeta.hasDecimalSize
+	}
+	return m.driverDecimalSize()
 }
 
 func (m *mockColumnType) Length() (length int64, ok bool) {
-	return m.length, m.length != 0
+	if m.copiedMeta != nil {
+		return m.copiedMeta.length, m.copiedMeta.hasLength
+	}
+	return m.driverLength()
 }
 
 func (m *mockColumnType) Name() string {
@@ -112,18 +318,63 @@ func (m *mockColumnType) Name() string {
 }
 
 func (m *mockColumnType) Nullable() (nullable bool, ok bool) {
-	return m.nullable, true
+	if m.copiedMeta != nil {
+		return m.copiedMeta.nullable, m.copiedMeta.hasNullable
+	}
+	return m.driverNullable()
 }
 
 func (m *mockColumnType) ScanType() reflect.Type {
 	return m.colType
 }
 
+// dialect reports the database the column type was created for
+func (m *mockColumnType) dialect() (DatabaseType, bool) {
+	return m.dbType, !m.noDialect
+}
+
+// Close ends the rows as sql.Rows does: Next reports false from then on and
+// the other methods fail. Closing twice is harmless.
 func (m *mockRowSet) Close() error {
+	m.markClosed()
 	return nil
 }
 
+func (m *mockRowSet) isClosed() bool {
+	m.closeMu.Lock()
+	defer m.closeMu.Unlock()
+	return m.closed
+}
+
+// markClosed closes the mock and wakes a Next that is waiting out row latency
+func (m *mockRowSet) markClosed() {
+	m.closeMu.Lock()
+	defer m.closeMu.Unlock()
+	if !m.closed {
+		m.closed = true
+		if m.stop != nil {
+			close(m.stop)
+		}
+	}
+}
+
+// stopped returns a channel that is closed once the mock is closed
+func (m *mockRowSet) stopped() <-chan struct{} {
+	m.closeMu.Lock()
+	defer m.closeMu.Unlock()
+	if m.stop == nil {
+		m.stop = make(chan struct{})
+		if m.closed {
+			close(m.stop)
+		}
+	}
+	return m.stop
+}
+
 func (m *mockRowSet) ColumnTypes() ([]ColumnType, error) {
+	if m.isClosed() || m.contextDone() {
+		return nil, m.closedErr()
+	}
 	list := make([]ColumnType, 0, len(m.columnTypes))
 	for _, ct := range m.columnTypes {
 		list = append(list, ct)
@@ -132,131 +383,160 @@ func (m *mockRowSet) ColumnTypes() ([]ColumnType, error) {
 }
 
 func (m *mockRowSet) Columns() ([]string, error) {
+	if m.isClosed() || m.contextDone() {
+		return nil, m.closedErr()
+	}
 	return m.columns, nil
 }
 
 func (m *mockRowSet) Err() error {
+	m.contextDone()
 	return m.err
 }
 
+// Next advances to the next row. Like sql.Rows, the mock closes itself when the
+// rows run out, unless another result set follows.
 func (m *mockRowSet) Next() bool {
-	if m.pos < len(m.values) {
+	if m.isClosed() || m.contextDone() {
+		return false
+	}
+	count := m.rowCount()
+	if m.pos < count {
+		m.wait(m.pos)
+		if m.isClosed() || m.contextDone() {
+			return false
+		}
+	}
+	if m.cfg.failErr != nil && m.pos == m.cfg.failAfter {
+		m.err = m.cfg.failErr
+		m.markClosed()
+		return false
+	}
+	if m.pos < count {
 		m.pos++
+		if m.generate != nil {
+			m.current = make([]any, len(m.columns))
+			copy(m.current, m.generate(m.pos-1))
+		}
 		return true
 	}
-	m.pos = len(m.values) + 1
+	m.pos = count + 1
+	if !m.hasNextSet {
+		m.markClosed()
+	}
 	return false
 }
 
+// NextResultSet reports false and closes the mock, as sql.Rows does when the
+// query produced a single result set. See NewMockResultSets for more than one.
 func (m *mockRowSet) NextResultSet() bool {
-	// Simulate a single result set by default; return false after first call
-	if m.hasNextSet {
-		m.hasNextSet = false
-		return true
-	}
+	m.markClosed()
 	return false
 }
 
 func (m *mockRowSet) Scan(dest ...any) error {
-	if m.pos == 0 {
-		return errors.New("sql: Scan called without calling Next")
+	if m.isClosed() || m.contextDone() {
+		return m.closedErr()
 	}
-	if m.pos > len(m.values) {
-		return fmt.Errorf("no more rows")
+	if m.pos == 0 || m.pos > m.rowCount() {
+		return errors.New("sql: Scan called without calling Next")
 	}
-	if len(dest) != len(m.values[m.pos-1]) {
-		return fmt.Errorf("destination length %d does not match row length %d", len(dest), len(m.values[m.pos-1]))
+
+	row := m.current
+	if m.generate == nil {
+		row = m.values[m.pos-1]
 	}
-	for i, val := range m.values[m.pos-1] {
-		nullable, _ := m.columnTypes[i].Nullable()
-		if nullable {
-			dest[i] = val
-		} else {
-			reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(val))
+	vals := make([]any, len(m.columns))
+	for i, val := range row {
+		if m.cfg.emulateDriver {
+			val = m.cfg.driverValue(val, m.columnTypes[i], m.dbType)
+		} else if m.columnTypes[i].declaredType {
+			val = m.cfg.storedTime(val, m.columnTypes[i], m.dbType)
 		}
+		vals[i] = val
 	}
-	return nil
+	return assignRow(dest, vals, m.columns)
 }
 
-func parseColumnSpec(colSpec string, dbType DatabaseType, row *mockRowSet) {
-	parts := strings.Split(colSpec, ";")
-	if len(parts) == 0 {
-		onPanic(fmt.Sprintf("empty column specification: %s", colSpec))
-		return
+// closedErr is what sql.Rows reports once closed: the error that ended
+// iteration, if any
+func (m *mockRowSet) closedErr() error {
+	if m.err != nil {
+		return m.err
 	}
+	return errRowsClosed
+}
 
-	var colName string
-	var typeStr string
-	var dbTypeStr string
-	var length *int64
-	var precision *int64
-	var scale *int64
+// splitColumnSpec reads the key=value pairs of a column spec, checking the keys
+// and the integer values; only the name is required
+func splitColumnSpec(colSpec string) (spec columnSpec, err error) {
+	parseInt := func(key, value string) (*int64, error) {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in column spec: %s", key, value)
+		}
+		return &n, nil
+	}
 
-	// Parse each key=value pair
-	for _, part := range parts {
+	for _, part := range strings.Split(colSpec, ";") {
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
 		}
 		kv := strings.SplitN(part, "=", 2)
 		if len(kv) != 2 {
-			onPanic(fmt.Sprintf("invalid key=value pair in column spec: %s", part))
-			return
+			return spec, fmt.Errorf("invalid key=value pair in column spec: %s", part)
 		}
 		key := strings.TrimSpace(kv[0])
 		value := strings.TrimSpace(kv[1])
 
 		switch key {
 		case "name":
-			colName = value
+			spec.name = value
 		case "type":
-			typeStr = value
+			spec.typeName = value
+		case "dbType":
+			spec.dbType = &value
 		case "length":
-			l, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				onPanic(fmt.Sprintf("invalid length in column spec: %s", value))
-				return
-			}
-			length = &l
+			spec.length, err = parseInt(key, value)
 		case "precision":
-			p, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				onPanic(fmt.Sprintf("invalid precision in column spec: %s", value))
-				return
-			}
-			precision = &p
+			spec.precision, err = parseInt(key, value)
 		case "scale":
-			s, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				onPanic(fmt.Sprintf("invalid scale in column spec: %s", value))
-				return
-			}
-			scale = &s
-		case "dbType":
-			dbTypeStr = value
+			spec.scale, err = parseInt(key, value)
 		default:
-			onPanic(fmt.Sprintf("unknown keyword in column spec: %s", key))
-			return
+			return spec, fmt.Errorf("unknown keyword in column spec: %s", key)
 		}
+		if err != nil {
+			return spec, err
+		}
+	}
+
+	if spec.name == "" {
+		return spec, fmt.Errorf("column spec missing required 'name': %s", colSpec)
 	}
+	return spec, nil
+}
 
-	// Validate required fields
-	if colName == "" {
-		onPanic(fmt.Sprintf("column spec missing required 'name': %s", colSpec))
+func parseColumnSpec(colSpec string, dbType DatabaseType, row *mockRowSet) {
+	spec, err := splitColumnSpec(colSpec)
+	if err != nil {
+		onPanic(err.Error())
 		return
 	}
-	if typeStr == "" {
+	if spec.typeName == "" {
 		onPanic(fmt.Sprintf("column spec missing required 'type': %s", colSpec))
 		return
 	}
+	colName := spec.name
+	length, precision, scale := spec.length, spec.precision, spec.scale
 
 	// Get Go type and default database type
-	goColType, defaultDbType, nullable := getColumnType(typeStr, dbType)
+	goColType, defaultDbType, nullable := getColumnType(spec.typeName, dbType)
 
 	// Use provided dbType if specified, otherwise use the default
 	dbColType := defaultDbType
-	if dbTypeStr != "" {
-		dbColType = dbTypeStr
+	if spec.dbType != nil && *spec.dbType != "" {
+		dbColType = *spec.dbType
 	}
 
 	// Load defaults
@@ -266,6 +546,9 @@ func parseColumnSpec(colSpec string, dbType DatabaseType, row *mockRowSet) {
 		return
 	}
 	defaults := defaultTable[dbColType]
+	if reported, found := reportedTypeNames[dbType][dbColType]; found {
+		dbColType = reported
+	}
 
 	if length == nil {
 		length = &defaults.length
@@ -273,6 +556,7 @@ func parseColumnSpec(colSpec string, dbType DatabaseType, row *mockRowSet) {
 	if precision == nil {
 		precision = &defaults.precision
 	}
+	hasScale := scale != nil
 	if scale == nil {
 		scale = &defaults.scale
 	}
@@ -285,7 +569,10 @@ func parseColumnSpec(colSpec string, dbType DatabaseType, row *mockRowSet) {
 		length:       *length,
 		precision:    *precision,
 		scale:        *scale,
+		hasScale:     hasScale,
+		declaredType: spec.dbType != nil && *spec.dbType != "",
 		databaseType: dbColType,
+		dbType:       dbType,
 	}
 
 	// Add to mockRowSet
@@ -1,6 +1,7 @@
 package sqlrows
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -146,16 +147,13 @@ func TestMockRowSetNextResultSet(t *testing.T) {
 			map[string]any{"ID": 2},
 		)
 
-	// Verify initial state: no next result set by default
-	mrs := it.rs.(*mockRowSet)
-	assert.False(it.t, it.rs.NextResultSet(), "Expected no next result set initially")
+	// a lone mock is a single result set, and asking for another closes it
+	assert.False(it.t, it.rs.NextResultSet(), "Expected no next result set")
+	assert.False(it.t, it.rs.Next(), "Expected the rows to be closed")
+	_, err := it.rs.Columns()
+	assert.EqualError(it.t, err, "sql: Rows are closed")
 
-	// Set hasNextSet to true to simulate multiple result sets
-	mrs.hasNextSet = true
-	assert.True(it.t, it.rs.NextResultSet(), "Expected next result set after setting hasNextSet")
-	assert.False(it.t, it.rs.NextResultSet(), "Expected no next result set after first call")
-
-	// Verify rows are still accessible after NextResultSet calls
+	// Rewind reopens the mock
 	it.VerifiesScan(
 		[]any{1},
 		[]any{2},
@@ -184,9 +182,9 @@ func TestMockColumnTypeDatabaseTypeName(t *testing.T) {
 func TestMockColumnTypeDecimalSize(t *testing.T) {
 	it := newTestCommon(t).
 		HasMockRowSet([]string{
-			"name=Price;type=float64;precision=10;scale=2", // Explicit precision and scale
-			"name=Amount;type=float64",                     // No precision/scale specified, defaults to 0
-			"name=ID;type=int64",                           // Non-decimal type, defaults to 0
+			"name=Price;type=float64;dbType=NUMBER;precision=10;scale=2", // Explicit precision and scale
+			"name=Amount;type=float64",                                   // Floats report no precision/scale
+			"name=ID;type=int64",                                         // Integers are NUMBER(38,0)
 		}, DbTypeSnowflake)
 
 	// Retrieve column types
@@ -206,9 +204,9 @@ func TestMockColumnTypeDecimalSize(t *testing.T) {
 	assert.False(it.t, ok, "Amount should not have valid decimal size")
 
 	precision, scale, ok = colTypes[2].DecimalSize() // ID
-	assert.Equal(it.t, int64(0), precision, "ID precision should be 0")
+	assert.Equal(it.t, int64(38), precision, "ID precision should be 38")
 	assert.Equal(it.t, int64(0), scale, "ID scale should be 0")
-	assert.False(it.t, ok, "ID should not have valid decimal size")
+	assert.True(it.t, ok, "ID should have valid decimal size")
 }
 
 func TestMockColumnTypeLength(t *testing.T) {
@@ -273,3 +271,169 @@ func TestMockRowSetScanWithoutNext(t *testing.T) {
 	// Verify no more rows
 	assert.False(it.t, it.rs.Next(), "Expected no more rows after scanning all")
 }
+
+func TestMockRowSetScanNullableIntoPointer(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=NAME;type=*string"}, DbTypeSnowflake)
+	name := "Ann"
+	it.rs.AddRow([]any{&name})
+	it.rs.AddRow([]any{nil})
+
+	// Scan fills a pointer destination the way sql.Rows does
+	dest := new(string)
+	assert.True(it.t, it.rs.Next())
+	assert.NoError(it.t, it.rs.Scan(&dest))
+	assert.Equal(it.t, "Ann", *dest)
+
+	assert.True(it.t, it.rs.Next())
+	assert.NoError(it.t, it.rs.Scan(&dest))
+	assert.Nil(it.t, dest)
+}
+
+func TestMockRowSetAddColumnWithDefault(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake).
+		AddsRowTables(
+			map[string]any{"ID": int64(1)},
+			map[string]any{"ID": int64(2)},
+		)
+
+	it.rs.AddColumn("name=NAME;type=string;length=32", "unknown")
+
+	it.VerifiesColumns([]string{"ID", "NAME"}).
+		VerifiesColumnTypes([]testColumnType{
+			{"ID", reflect.TypeOf(int64(0)), "BIGINT", false, 0, 0, 0},
+			{"NAME", reflect.TypeOf(""), "VARCHAR", false, 32, 0, 0},
+		}).
+		VerifiesScan(
+			[]any{int64(1), "unknown"},
+			[]any{int64(2), "unknown"},
+		)
+}
+
+func TestMockRowSetAddColumnNullable(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64"}, DbTypePostgresSQL).
+		AddsRowTables(map[string]any{"ID": int64(1)})
+
+	it.rs.AddColumn("name=TS;type=*time.Time", nil)
+	it.AddsRowTables(map[string]any{"ID": int64(2), "TS": &it.now})
+
+	it.VerifiesScan(
+		[]any{int64(1), nil},
+		[]any{int64(2), &it.now},
+	)
+}
+
+func TestMockRowSetAddColumnDuplicate(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake).
+		AddsRowTables(map[string]any{"ID": int64(1)}).
+		HooksPanic()
+
+	it.rs.AddColumn("name=id;type=string", "x")
+
+	it.ExpectedPanic("duplicate column name in mock row set").
+		VerifiesColumns([]string{"ID"})
+}
+
+func TestMockRowSetDropColumn(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string", "name=CODE;type=int"}, DbTypeMsSQL).
+		AddsRows([][]any{
+			{int64(1), "one", 10},
+			{int64(2), "two", 20},
+		})
+
+	it.rs.DropColumn("name")
+
+	it.VerifiesColumns([]string{"ID", "CODE"}).
+		VerifiesScan(
+			[]any{int64(1), 10},
+			[]any{int64(2), 20},
+		)
+
+	// lookups must follow the new positions
+	it.AddsRowTables(map[string]any{"CODE": 30, "ID": int64(3)})
+	it.VerifiesScan(
+		[]any{int64(1), 10},
+		[]any{int64(2), 20},
+		[]any{int64(3), 30},
+	)
+}
+
+func TestMockRowSetDropColumnMissing(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64"}, DbTypeMsSQL).
+		HooksPanic()
+
+	it.rs.DropColumn("XYZ")
+
+	it.ExpectedPanic("column XYZ does not exist")
+}
+
+func TestMockRowSetRenameColumn(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string"}, DbTypeSnowflake).
+		AddsRowTables(map[string]any{"ID": int64(1), "NAME": "one"})
+
+	before, err := it.rs.ColumnTypes()
+	require.NoError(t, err)
+
+	it.rs.RenameColumn("name", "LABEL")
+
+	it.VerifiesColumns([]string{"ID", "LABEL"}).
+		VerifiesColumnTypes([]testColumnType{
+			{"ID", reflect.TypeOf(int64(0)), "BIGINT", false, 0, 0, 0},
+			{"LABEL", reflect.TypeOf(""), "VARCHAR", false, 16777216, 0, 0},
+		}).
+		AddsRowTables(map[string]any{"ID": int64(2), "label": "two"}).
+		VerifiesScan(
+			[]any{int64(1), "one"},
+			[]any{int64(2), "two"},
+		)
+
+	assert.Equal(t, "NAME", before[1].Name(), "previously returned column types should not change")
+}
+
+func TestMockRowSetRenameColumnDuplicate(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string"}, DbTypeSnowflake).
+		HooksPanic()
+
+	it.rs.RenameColumn("NAME", "id")
+
+	it.ExpectedPanic("duplicate column name in mock row set: id")
+}
+
+func TestMockRowSetReorderColumns(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string", "name=CODE;type=int"}, DbTypePostgresSQL).
+		AddsRows([][]any{
+			{int64(1), "one", 10},
+			{int64(2), "two", 20},
+		})
+
+	it.rs.ReorderColumns("code", "ID", "Name")
+
+	it.VerifiesColumns([]string{"CODE", "ID", "NAME"}).
+		VerifiesColumnTypes([]testColumnType{
+			{"CODE", reflect.TypeOf(0), "INTEGER", false, 0, 0, 0},
+			{"ID", reflect.TypeOf(int64(0)), "BIGINT", false, 0, 0, 0},
+			{"NAME", reflect.TypeOf(""), "TEXT", false, math.MaxInt64, 0, 0},
+		}).
+		VerifiesScan(
+			[]any{10, int64(1), "one"},
+			[]any{20, int64(2), "two"},
+		)
+}
+
+func TestMockRowSetReorderColumnsIncomplete(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string"}, DbTypePostgresSQL).
+		HooksPanic()
+
+	it.rs.ReorderColumns("ID", "ID")
+
+	it.ExpectedPanic("column ID listed more than once")
+}
@@ -0,0 +1,27 @@
+package sqlrows
+
+import "context"
+
+// Binds the mock's rows to [ctx], the way sql.Rows is bound to the context of
+// QueryContext. Once [ctx] is cancelled or its deadline passes, Next reports
+// false, Err and Scan return ctx.Err(), and the rows are closed. Rows that were
+// read to the end before then are not affected.
+func BindContext(ctx context.Context) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.ctx = ctx
+	}
+}
+
+// contextDone ends iteration with the bound context's error once the context is
+// done, unless the rows already ended
+func (m *mockRowSet) contextDone() bool {
+	if m.cfg.ctx == nil || m.isClosed() {
+		return false
+	}
+	if err := m.cfg.ctx.Err(); err != nil {
+		m.err = err
+		m.markClosed()
+		return true
+	}
+	return false
+}
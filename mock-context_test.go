@@ -0,0 +1,54 @@
+package sqlrows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, BindContext(ctx))
+	rs.AddRow([]any{int64(1)})
+	rs.AddRow([]any{int64(2)})
+
+	require.True(t, rs.Next())
+	var id int64
+	require.NoError(t, rs.Scan(&id))
+
+	cancel()
+	assert.ErrorIs(t, rs.Scan(&id), context.Canceled)
+	assert.False(t, rs.Next())
+	assert.ErrorIs(t, rs.Err(), context.Canceled)
+	_, err := rs.Columns()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBindContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypePostgresSQL, BindContext(ctx))
+	rs.AddRow([]any{int64(1)})
+
+	assert.False(t, rs.Next())
+	assert.ErrorIs(t, rs.Err(), context.DeadlineExceeded)
+}
+
+func TestBindContextAfterRowsEnd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeMsSQL, BindContext(ctx))
+	rs.AddRow([]any{int64(1)})
+
+	require.True(t, rs.Next())
+	assert.False(t, rs.Next())
+	cancel()
+
+	// the rows ended on their own, so the cancellation is not reported
+	assert.NoError(t, rs.Err())
+}
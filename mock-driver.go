@@ -0,0 +1,270 @@
+package sqlrows
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type (
+	MockOption func(cfg *mockConfig)
+
+	mockConfig struct {
+		emulateDriver bool
+		sessionZone   *time.Location
+		failAfter     int
+		failErr       error
+		ctx           context.Context
+		latency       latencyConfig
+	}
+)
+
+// Makes Scan deliver each value the way the dialect's driver does, then convert
+// it with the database/sql rules: gosnowflake returns NUMBER as int64, or as a
+// string when the scale is not zero; lib/pq returns NUMERIC, UUID and JSON as
+// []byte text; go-mssqldb returns DECIMAL as []byte text and UNIQUEIDENTIFIER as
+// 16 bytes with the first three groups byte-swapped. Integers arrive as int64 and
+// floats as float64. Times are cut to the column's precision and keep, normalize
+// or drop their zone as the column type does, which columns with a declared
+// time dbType get even without emulation. Scan destinations must be pointers,
+// as with sql.Rows.
+func EmulateDriver() MockOption {
+	return func(cfg *mockConfig) {
+		cfg.emulateDriver = true
+	}
+}
+
+// driverValue converts a fixture value to what the dialect's driver delivers
+// for the column
+func (cfg *mockConfig) driverValue(v any, ct *mockColumnType, dbType DatabaseType) any {
+	v = normalizeValue(v)
+	if v == nil {
+		return nil
+	}
+
+	decl := driverTypeDecl(ct.databaseType)
+	if dbType == DbTypePostgresSQL {
+		if dv, handled := postgresDriverValue(v, decl); handled {
+			return dv
+		}
+	}
+	if decl.class() == "decimal" {
+		// the spec's scale wins over the type's arguments, as in DecimalSize
+		scale := ct.scale
+		if numbers, _ := decl.sizes(); scale == 0 && len(numbers) > 1 {
+			scale = numbers[1]
+		}
+		text := decimalText(v, scale)
+		if dbType == DbTypeSnowflake {
+			if scale == 0 {
+				if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+					return i
+				}
+			}
+			return text
+		}
+		return []byte(text)
+	}
+
+	var names map[string]string
+	switch dbType {
+	case DbTypeSnowflake:
+		names = goTypesSnowflake
+	case DbTypePostgresSQL:
+		names = goTypesPostgres
+	case DbTypeMsSQL:
+		names = goTypesMsSql
+	}
+	name, found := names[decl.name]
+	if !found {
+		return driverBytes(v)
+	}
+
+	switch name {
+	case "int8", "int16", "int32", "int64", "uint8":
+		if i, ok := intValue(v); ok {
+			return i
+		}
+	case "float32", "float64":
+		if f, ok := numericValue(v); ok {
+			return f
+		}
+	case "bool":
+		return v
+	case "time.Time":
+		if t, ok := v.(time.Time); ok {
+			return cfg.driverTime(t, decl, ct, dbType)
+		}
+	case "[]byte":
+		return driverBytes(v)
+	case "any":
+		return variantValue(v, dbType)
+	case "string":
+		if isJSONType(decl.name) || isCollection(v) {
+			return jsonText(v, dbType)
+		}
+		// lib/pq delivers JSON and other non-character types as text bytes
+		if dbType == DbTypePostgresSQL && !isPostgresCharacterType(decl.name) {
+			return []byte(valueString(v))
+		}
+		text := valueString(v)
+		if isFixedCharType(decl.name) && dbType != DbTypeSnowflake {
+			// fixed-length values come back padded with spaces
+			if pad := int(ct.length) - utf8.RuneCountInString(text); pad > 0 {
+				text += strings.Repeat(" ", pad)
+			}
+		}
+		return text
+	case "uuid.UUID":
+		u, ok := v.(uuid.UUID)
+		if !ok {
+			parsed, err := uuid.Parse(valueString(v))
+			if err != nil {
+				return driverBytes(v)
+			}
+			u = parsed
+		}
+		if dbType == DbTypeMsSQL {
+			return mssqlGUIDBytes(u)
+		}
+		return []byte(u.String())
+	}
+	return v
+}
+
+// driverTypeDecl parses a column's database type name, such as NVARCHAR(MAX)
+func driverTypeDecl(databaseType string) sqlTypeDecl {
+	tokens, err := tokenizeDDL(databaseType)
+	if err != nil {
+		return sqlTypeDecl{}
+	}
+	decl, _, _ := parseSQLType(tokens, 0)
+	return decl
+}
+
+// isJSONType tells whether a type holds JSON documents, which drivers deliver as JSON text
+func isJSONType(name string) bool {
+	switch name {
+	case "VARIANT", "OBJECT", "ARRAY", "JSON", "JSONB":
+		return true
+	}
+	return false
+}
+
+// jsonText renders a semi-structured fixture value as JSON text: gosnowflake
+// delivers a pretty-printed string, lib/pq the document as bytes. Strings and
+// byte slices are taken to be JSON already.
+func jsonText(v any, dbType DatabaseType) any {
+	text := valueString(v)
+	switch v.(type) {
+	case string, []byte, json.RawMessage:
+	default:
+		var b []byte
+		var err error
+		if dbType == DbTypeSnowflake {
+			b, err = json.MarshalIndent(v, "", "  ")
+		} else {
+			b, err = json.Marshal(v)
+		}
+		if err == nil {
+			text = string(b)
+		}
+	}
+	if dbType == DbTypePostgresSQL {
+		return []byte(text)
+	}
+	return text
+}
+
+// isCollection tells whether a fixture value is a slice or map other than bytes,
+// which a text column holds as JSON
+func isCollection(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice && !isBytesKind(rv.Type())
+}
+
+func isFixedCharType(name string) bool {
+	switch name {
+	case "CHAR", "CHARACTER", "NCHAR", "BPCHAR":
+		return true
+	}
+	return false
+}
+
+// variantValue is what go-mssqldb delivers for a SQL_VARIANT: the stored
+// value widened the way a column of its own type would be
+func variantValue(v any, dbType DatabaseType) any {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return rv.Int()
+	case rv.CanUint():
+		return int64(rv.Uint())
+	case rv.CanFloat():
+		return rv.Float()
+	}
+	if u, ok := v.(uuid.UUID); ok && dbType == DbTypeMsSQL {
+		return mssqlGUIDBytes(u)
+	}
+	return v
+}
+
+func isPostgresCharacterType(name string) bool {
+	switch name {
+	case "TEXT", "VARCHAR", "CHARACTER VARYING", "CHAR", "CHARACTER", "BPCHAR", "NAME", "CITEXT":
+		return true
+	}
+	return false
+}
+
+// decimalText renders a number with [scale] digits after the point, the way
+// drivers deliver fixed-point values
+func decimalText(v any, scale int64) string {
+	if _, isInt := intValue(v); isInt && scale == 0 {
+		return valueString(v)
+	}
+	if f, ok := numericValue(v); ok {
+		return strconv.FormatFloat(f, 'f', int(scale), 64)
+	}
+	return valueString(v)
+}
+
+// intValue widens an integer, or a float without a fraction, to int64
+func intValue(v any) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return rv.Int(), true
+	case rv.CanUint():
+		return int64(rv.Uint()), true
+	case rv.CanFloat() && rv.Float() == float64(int64(rv.Float())):
+		return int64(rv.Float()), true
+	}
+	return 0, false
+}
+
+// driverBytes is the text form a driver delivers for a type it does not decode
+func driverBytes(v any) any {
+	switch tv := v.(type) {
+	case []byte, time.Time, bool:
+		return tv
+	}
+	return []byte(valueString(v))
+}
+
+// mssqlGUIDBytes lays out a UUID the way SQL Server stores UNIQUEIDENTIFIER: the
+// first three groups are little-endian
+func mssqlGUIDBytes(u uuid.UUID) []byte {
+	b := make([]byte, 16)
+	copy(b, u[:])
+	b[0], b[1], b[2], b[3] = u[3], u[2], u[1], u[0]
+	b[4], b[5] = u[5], u[4]
+	b[6], b[7] = u[7], u[6]
+	return b
+}
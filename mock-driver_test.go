@@ -0,0 +1,182 @@
+package sqlrows
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scanAny reads the driver representation of the next row
+func scanAny(t *testing.T, rs RowSet) []any {
+	cols, err := rs.Columns()
+	require.NoError(t, err)
+	require.True(t, rs.Next())
+	vals := make([]any, len(cols))
+	dest := make([]any, len(cols))
+	for i := range vals {
+		dest[i] = &vals[i]
+	}
+	require.NoError(t, rs.Scan(dest...))
+	return vals
+}
+
+func TestEmulateDriverSnowflake(t *testing.T) {
+	rs := NewMockRowSet([]string{
+		"name=ID;type=int32",
+		"name=PRICE;type=float64;dbType=NUMBER;precision=12;scale=2",
+		"name=QTY;type=int64;dbType=NUMBER",
+		"name=RATIO;type=float32",
+		"name=KEY;type=uuid.UUID",
+		"name=NOTE;type=*string",
+	}, DbTypeSnowflake, EmulateDriver())
+	key := uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	rs.AddRow([]any{int32(7), 9.5, int64(3), float32(0.5), key, nil})
+
+	assert.Equal(t, []any{int64(7), "9.50", int64(3), 0.5, key.String(), nil}, scanAny(t, rs))
+
+	// conversion rules then apply to typed destinations
+	rs.Rewind()
+	require.True(t, rs.Next())
+	var id int
+	var price float64
+	var qty string
+	var ratio float32
+	var k uuid.UUID
+	var note *string
+	require.NoError(t, rs.Scan(&id, &price, &qty, &ratio, &k, &note))
+	assert.Equal(t, 7, id)
+	assert.Equal(t, 9.5, price)
+	assert.Equal(t, "3", qty)
+	assert.Equal(t, float32(0.5), ratio)
+	assert.Equal(t, key, k)
+	assert.Nil(t, note)
+}
+
+func TestSnowflakeSemiStructuredColumns(t *testing.T) {
+	rs := NewMockRowSet([]string{
+		"name=DOC;type=json.RawMessage",
+		"name=ATTRS;type=*string;dbType=OBJECT",
+		"name=TAGS;type=string;dbType=ARRAY",
+		"name=AT;type=time.Time",
+	}, DbTypeSnowflake, EmulateDriver())
+	at := time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC)
+	rs.AddRow([]any{json.RawMessage(`{"a":1}`), map[string]any{"b": true}, []string{"x", "y"}, at})
+
+	cts, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	names := make([]string, len(cts))
+	for i, ct := range cts {
+		names[i] = ct.DatabaseTypeName()
+	}
+	assert.Equal(t, []string{"VARIANT", "OBJECT", "ARRAY", "TIMESTAMP_NTZ"}, names)
+
+	// gosnowflake delivers semi-structured values as JSON text
+	assert.Equal(t, []any{`{"a":1}`, "{\n  \"b\": true\n}", "[\n  \"x\",\n  \"y\"\n]", at}, scanAny(t, rs))
+
+	rs.Rewind()
+	require.True(t, rs.Next())
+	var doc json.RawMessage
+	var attrs []byte
+	var tags string
+	var shipped time.Time
+	require.NoError(t, rs.Scan(&doc, &attrs, &tags, &shipped))
+	assert.JSONEq(t, `{"a":1}`, string(doc))
+	assert.JSONEq(t, `{"b":true}`, string(attrs))
+	assert.JSONEq(t, `["x","y"]`, tags)
+	assert.Equal(t, at, shipped)
+}
+
+func TestEmulateDriverPostgres(t *testing.T) {
+	rs := NewMockRowSet([]string{
+		"name=id;type=int16",
+		"name=total;type=float64;dbType=NUMERIC;precision=10;scale=2",
+		"name=key;type=uuid.UUID",
+		"name=doc;type=string;dbType=JSONB",
+		"name=name;type=string",
+	}, DbTypePostgresSQL, EmulateDriver())
+	key := uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	rs.AddRow([]any{int16(2), 12.5, key, `{"a":1}`, "Ann"})
+
+	assert.Equal(t, []any{int64(2), []byte("12.50"), []byte(key.String()), []byte(`{"a":1}`), "Ann"}, scanAny(t, rs))
+
+	// the scale may come from the type's arguments instead
+	rs = NewMockRowSet([]string{"name=total;type=float64;dbType=NUMERIC(10,2)"}, DbTypePostgresSQL, EmulateDriver())
+	rs.AddRow([]any{12.5})
+	assert.Equal(t, []any{[]byte("12.50")}, scanAny(t, rs))
+}
+
+func TestEmulateDriverMsSQLGUID(t *testing.T) {
+	rs := NewMockRowSet([]string{
+		"name=Id;type=uuid.UUID",
+		"name=Amount;type=float64;dbType=DECIMAL;precision=19;scale=4",
+		"name=Flag;type=bool",
+		"name=Small;type=uint8",
+	}, DbTypeMsSQL, EmulateDriver())
+	key := uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	rs.AddRow([]any{key, 1.25, true, uint8(200)})
+
+	swapped := []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+	assert.Equal(t, []any{swapped, []byte("1.2500"), true, int64(200)}, scanAny(t, rs))
+
+	// scanning the GUID straight into uuid.UUID yields the wrong value, as with go-mssqldb
+	rs.Rewind()
+	require.True(t, rs.Next())
+	var id uuid.UUID
+	var amount float64
+	var flag bool
+	var small uint8
+	require.NoError(t, rs.Scan(&id, &amount, &flag, &small))
+	assert.NotEqual(t, key, id)
+	assert.Equal(t, "33221100-5544-7766-8899-aabbccddeeff", id.String())
+	assert.Equal(t, 1.25, amount)
+}
+
+func TestMsSQLTypes(t *testing.T) {
+	rs := NewMockRowSet([]string{
+		"name=Billed;type=time.Time;dbType=DATETIMEOFFSET",
+		"name=Posted;type=time.Time;dbType=SMALLDATETIME",
+		"name=Fee;type=float64;dbType=MONEY",
+		"name=Petty;type=float64;dbType=SMALLMONEY",
+		"name=Doc;type=string;dbType=XML",
+		"name=Tag;type=any",
+		"name=Version;type=[]byte;dbType=ROWVERSION",
+		"name=Blob;type=[]byte",
+		"name=Code;type=string;dbType=NCHAR;length=4",
+		"name=Name;type=*string;dbType=NVARCHAR;length=50",
+	}, DbTypeMsSQL, EmulateDriver())
+	billed := time.Date(2024, 6, 1, 8, 30, 15, 0, time.FixedZone("EST", -5*3600))
+	rs.AddRow([]any{billed, billed, 12.5, -0.25, "<a/>", int32(7), []byte{0, 0, 0, 0, 0, 0, 0, 9}, []byte{1}, "AB", "Ann"})
+
+	cts, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	names := make([]string, len(cts))
+	lengths := make([]int64, len(cts))
+	for i, ct := range cts {
+		names[i] = ct.DatabaseTypeName()
+		lengths[i], _ = ct.Length()
+	}
+	assert.Equal(t, []string{"DATETIMEOFFSET", "SMALLDATETIME", "MONEY", "SMALLMONEY", "XML", "SQL_VARIANT", "BINARY", "VARBINARY(MAX)", "NCHAR", "NVARCHAR"}, names)
+	// character types report their length in characters
+	assert.Equal(t, []int64{0, 0, 0, 0, 1073741822, 0, 8, 2147483645, 4, 50}, lengths)
+
+	vals := scanAny(t, rs)
+	assert.True(t, billed.Equal(vals[0].(time.Time)))
+	_, offset := vals[0].(time.Time).Zone()
+	assert.Equal(t, -5*3600, offset)
+	assert.Equal(t, time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC), vals[1])
+	assert.Equal(t, []any{[]byte("12.5000"), []byte("-0.2500"), "<a/>", int64(7), []byte{0, 0, 0, 0, 0, 0, 0, 9}, []byte{1}, "AB  ", "Ann"}, vals[2:])
+}
+
+func TestEmulateDriverScanErrors(t *testing.T) {
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, EmulateDriver())
+	rs.AddRow([]any{nil})
+	require.True(t, rs.Next())
+
+	var id int64
+	assert.EqualError(t, rs.Scan(&id), `sql: Scan error on column index 0, name "ID": converting NULL to int64 is unsupported`)
+	assert.EqualError(t, rs.Scan(id), `sql: Scan error on column index 0, name "ID": destination not a pointer`)
+}
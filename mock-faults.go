@@ -0,0 +1,12 @@
+package sqlrows
+
+// Makes Next fail with [err] once [rows] rows have been read, the way a
+// connection lost mid-query surfaces through sql.Rows: Next reports false, Err
+// and Scan return [err], and the rows are closed. Rewind clears the failure so
+// it happens again on the next pass.
+func FailAfter(rows int, err error) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.failAfter = rows
+		cfg.failErr = err
+	}
+}
@@ -0,0 +1,46 @@
+package sqlrows
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailAfter(t *testing.T) {
+	lost := errors.New("connection lost")
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypePostgresSQL, FailAfter(2, lost))
+	rs.AddRow([]any{int64(1)})
+	rs.AddRow([]any{int64(2)})
+	rs.AddRow([]any{int64(3)})
+
+	for range 2 {
+		var ids []int64
+		for rs.Next() {
+			var id int64
+			require.NoError(t, rs.Scan(&id))
+			ids = append(ids, id)
+		}
+		assert.Equal(t, []int64{1, 2}, ids)
+		assert.ErrorIs(t, rs.Err(), lost)
+		assert.False(t, rs.Next())
+
+		var id int64
+		assert.ErrorIs(t, rs.Scan(&id), lost)
+		_, err := rs.Columns()
+		assert.ErrorIs(t, err, lost)
+
+		// the failure happens again after a rewind
+		rs.Rewind()
+	}
+}
+
+func TestFailAfterNoRows(t *testing.T) {
+	lost := errors.New("connection lost")
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeMsSQL, FailAfter(0, lost))
+	rs.AddRow([]any{int64(1)})
+
+	assert.False(t, rs.Next())
+	assert.ErrorIs(t, rs.Err(), lost)
+}
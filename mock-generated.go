@@ -0,0 +1,30 @@
+package sqlrows
+
+// Creates a mock table of [n] rows that are produced on demand: each call to
+// Next asks [gen] for the values of row [i], counting from 0, so a huge result
+// set can be streamed without holding it in memory. [cols] and [opts] are the
+// same as for NewMockRowSet, and the rows are scanned, delayed and failed the
+// same way. Values missing from the end of a generated row are nil.
+func NewGeneratedRowSet(cols []string, dbType DatabaseType, n int, gen func(i int) []any, opts ...MockOption) RowSet {
+	if gen == nil {
+		onPanic("a row generator is required")
+		return nil
+	}
+	if n < 0 {
+		onPanic("row count cannot be negative")
+		return nil
+	}
+
+	rs := NewMockRowSet(cols, dbType, opts...).(*mockRowSet)
+	rs.generate = gen
+	rs.count = n
+	return rs
+}
+
+// rowCount is the number of rows the mock serves
+func (m *mockRowSet) rowCount() int {
+	if m.generate != nil {
+		return m.count
+	}
+	return len(m.values)
+}
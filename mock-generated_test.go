@@ -0,0 +1,98 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedRowSet(t *testing.T) {
+	calls := 0
+	rs := NewGeneratedRowSet([]string{"name=ID;type=int64", "name=NAME;type=string", "name=NOTE;type=*string"}, DbTypePostgresSQL, 10_000_000,
+		func(i int) []any {
+			calls++
+			return []any{int64(i), fmt.Sprintf("row %d", i)}
+		})
+
+	cols, err := rs.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "NAME", "NOTE"}, cols)
+	assert.Equal(t, 0, calls, "rows should not be produced before Next")
+
+	for i := range 3 {
+		require.True(t, rs.Next())
+		var id int64
+		var name string
+		var note *string
+		require.NoError(t, rs.Scan(&id, &name, &note))
+		require.NoError(t, rs.Scan(&id, &name, &note))
+		assert.Equal(t, int64(i), id)
+		assert.Equal(t, fmt.Sprintf("row %d", i), name)
+		assert.Nil(t, note)
+	}
+	assert.Equal(t, 3, calls, "each row should be produced once, when Next reaches it")
+	require.NoError(t, rs.Close())
+}
+
+func TestGeneratedRowSetOptions(t *testing.T) {
+	lost := errors.New("connection lost")
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rs := NewGeneratedRowSet([]string{"name=AMOUNT;type=float64;dbType=NUMERIC;precision=10;scale=2"}, DbTypePostgresSQL, 100,
+		func(i int) []any { return []any{float64(i) + 0.5} },
+		EmulateDriver(), FailAfter(2, lost), WithClock(clock), RowLatency(time.Second))
+
+	var amounts []any
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for rs.Next() {
+			var v any
+			assert.NoError(t, rs.Scan(&v))
+			amounts = append(amounts, v)
+		}
+	}()
+	// a second per row, and a third wait before the failure
+	for range 3 {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	<-done
+
+	assert.Equal(t, []any{[]byte("0.50"), []byte("1.50")}, amounts)
+	assert.ErrorIs(t, rs.Err(), lost)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC), clock.Now())
+}
+
+func TestGeneratedRowSetConformance(t *testing.T) {
+	RunRowSetConformance(t, func(f ConformanceFixture) (RowSet, error) {
+		sets := make([]MockRowSet, 0, len(f.ResultSets))
+		for _, set := range f.ResultSets {
+			var opts []MockOption
+			if set.Err != nil {
+				opts = append(opts, FailAfter(set.FailAfter, set.Err))
+			}
+			rows := slices.Clone(set.Rows)
+			gen := func(i int) []any { return rows[i] }
+			sets = append(sets, NewGeneratedRowSet(set.Columns, f.DbType, len(rows), gen, opts...).(MockRowSet))
+		}
+		if len(sets) == 1 {
+			return sets[0], nil
+		}
+		return NewMockResultSets(sets...), nil
+	})
+}
+
+func TestGeneratedRowSetInvalid(t *testing.T) {
+	it := newTestCommon(t).HooksPanic()
+	NewGeneratedRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, 1, nil)
+	it.ExpectedPanic("a row generator is required")
+
+	it = newTestCommon(t).HooksPanic()
+	NewGeneratedRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, -1, func(int) []any { return nil })
+	it.ExpectedPanic("row count cannot be negative")
+}
@@ -0,0 +1,210 @@
+package sqlrows
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+type (
+	// Clock is the time source a mock waits on for simulated latency
+	Clock interface {
+		Now() time.Time
+		After(d time.Duration) <-chan time.Time
+	}
+
+	// FakeClock is a Clock that only moves when told to: a wait started with
+	// After completes once Advance has moved the clock past it, so latency and
+	// timeout tests run instantly and deterministically
+	FakeClock struct {
+		mu      sync.Mutex
+		now     time.Time
+		waiters []fakeWaiter
+		changed *sync.Cond
+	}
+
+	// fakeWaiter is a pending After call on a FakeClock
+	fakeWaiter struct {
+		deadline time.Time
+		ch       chan time.Time
+	}
+
+	systemClock struct{}
+
+	latencyConfig struct {
+		clock      Clock
+		perRow     time.Duration
+		firstRow   time.Duration
+		jitter     time.Duration
+		seed       uint64
+		rng        *rand.Rand
+		stallEvery int
+		stall      time.Duration
+	}
+)
+
+// Makes Next wait [d] before each row, simulating the time a driver takes to
+// fetch it.
+func RowLatency(d time.Duration) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.latency.perRow = d
+	}
+}
+
+// Makes Next wait [d] before the first row, in addition to any row latency,
+// simulating the time the database takes to start returning results.
+func FirstRowLatency(d time.Duration) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.latency.firstRow = d
+	}
+}
+
+// Adds a random wait of up to [max] before each row, drawn from a random number
+// generator seeded with [seed]. Each pass over the rows, after a Rewind, waits
+// the same sequence of times.
+func Jitter(max time.Duration, seed uint64) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.latency.jitter = max
+		cfg.latency.seed = seed
+		cfg.latency.rng = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// Makes Next pause for [d] before every [every]th row after the first, as a
+// stream does while the driver waits on the next chunk of results.
+func Stall(every int, d time.Duration) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.latency.stallEvery = every
+		cfg.latency.stall = d
+	}
+}
+
+// Sets the clock the mock waits on for simulated latency. The default is the
+// system clock; a FakeClock keeps tests fast.
+func WithClock(clock Clock) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.latency.clock = clock
+	}
+}
+
+// Creates a FakeClock reading [start].
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the clock's time once Advance has
+// moved the clock [d] or more past the current time. A wait of zero or less
+// is ready at once.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: c.now.Add(d), ch: ch})
+	c.cond().Broadcast()
+	return ch
+}
+
+// Advance moves the clock forward by [d], completing the waits that are due,
+// and returns the new time.
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			pending = append(pending, w)
+		} else {
+			w.ch <- c.now
+		}
+	}
+	clear(c.waiters[len(pending):])
+	c.waiters = pending
+	return c.now
+}
+
+// BlockUntil returns once at least [waiters] After calls are waiting on the
+// clock, so a test can advance the clock knowing the code under test is
+// blocked on it. Waits that were abandoned, such as one cut short by a
+// cancelled context, still count until the clock passes them.
+func (c *FakeClock) BlockUntil(waiters int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < waiters {
+		c.cond().Wait()
+	}
+}
+
+// cond is signalled when a wait starts; the caller holds c.mu
+func (c *FakeClock) cond() *sync.Cond {
+	if c.changed == nil {
+		c.changed = sync.NewCond(&c.mu)
+	}
+	return c.changed
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// rowDelay is how long Next waits before delivering the row at [index]
+func (lc *latencyConfig) rowDelay(index int) time.Duration {
+	d := lc.perRow
+	if index == 0 {
+		d += lc.firstRow
+	}
+	if lc.stallEvery > 0 && index > 0 && index%lc.stallEvery == 0 {
+		d += lc.stall
+	}
+	if lc.jitter > 0 {
+		d += time.Duration(lc.rng.Int64N(int64(lc.jitter)))
+	}
+	return d
+}
+
+// reset restarts the jitter sequence for another pass over the rows
+func (lc *latencyConfig) reset() {
+	if lc.rng != nil {
+		lc.rng = rand.New(rand.NewPCG(lc.seed, lc.seed))
+	}
+}
+
+// wait sleeps on the mock's clock before the row at [index] is delivered,
+// returning early if the mock is closed or the bound context is done
+func (m *mockRowSet) wait(index int) {
+	d := m.cfg.latency.rowDelay(index)
+	if d <= 0 {
+		return
+	}
+
+	clock := m.cfg.latency.clock
+	if clock == nil {
+		clock = systemClock{}
+	}
+	var done <-chan struct{}
+	if m.cfg.ctx != nil {
+		done = m.cfg.ctx.Done()
+	}
+	select {
+	case <-clock.After(d):
+	case <-m.stopped():
+	case <-done:
+	}
+}
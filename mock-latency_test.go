@@ -0,0 +1,124 @@
+package sqlrows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rowWaits reads the [rows] rows of [rs], advancing [clock] to the end of each
+// wait once Next blocks on it, and returns how far the clock moved for each row
+func rowWaits(t *testing.T, rs RowSet, clock *FakeClock, rows int) []time.Duration {
+	waits := make([]time.Duration, 0, rows)
+	next := make(chan bool)
+	for range rows {
+		go func() { next <- rs.Next() }()
+		clock.BlockUntil(1)
+		clock.mu.Lock()
+		wait := clock.waiters[0].deadline.Sub(clock.now)
+		clock.mu.Unlock()
+		clock.Advance(wait)
+		require.True(t, <-next)
+		waits = append(waits, wait)
+	}
+	require.False(t, rs.Next())
+	require.NoError(t, rs.Err())
+	return waits
+}
+
+func newLatencyFixture(t *testing.T, rows int, opts ...MockOption) MockRowSet {
+	values := make([][]any, rows)
+	for i := range values {
+		values[i] = []any{int64(i)}
+	}
+	return newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, opts...).
+		AddsRows(values).rs
+}
+
+func TestRowLatency(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rs := newLatencyFixture(t, 3, WithClock(clock), RowLatency(10*time.Millisecond), FirstRowLatency(time.Second))
+
+	assert.Equal(t, []time.Duration{1010 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}, rowWaits(t, rs, clock, 3))
+}
+
+func TestStall(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rs := newLatencyFixture(t, 5, WithClock(clock), RowLatency(time.Millisecond), Stall(2, 5*time.Second))
+
+	ms := time.Millisecond
+	assert.Equal(t, []time.Duration{ms, ms, 5*time.Second + ms, ms, 5*time.Second + ms}, rowWaits(t, rs, clock, 5))
+}
+
+func TestJitter(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rs := newLatencyFixture(t, 20, WithClock(clock), Jitter(time.Second, 42))
+
+	first := rowWaits(t, rs, clock, 20)
+	for _, wait := range first {
+		assert.Less(t, wait, time.Second)
+	}
+
+	// a rewind replays the same waits, and so does the same seed
+	rs.Rewind()
+	assert.Equal(t, first, rowWaits(t, rs, clock, 20))
+	again := newLatencyFixture(t, 20, WithClock(clock), Jitter(time.Second, 42))
+	assert.Equal(t, first, rowWaits(t, again, clock, 20))
+
+	other := newLatencyFixture(t, 20, WithClock(clock), Jitter(time.Second, 7))
+	assert.NotEqual(t, first, rowWaits(t, other, clock, 20))
+}
+
+func TestFakeClockAfter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	ch := clock.After(time.Second)
+	clock.BlockUntil(1)
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-ch:
+		t.Fatal("the wait completed before the clock reached it")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-ch)
+
+	// a wait of zero is ready at once
+	assert.Equal(t, start.Add(time.Second), <-clock.After(0))
+}
+
+func TestRowLatencyTimeout(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rs := newLatencyFixture(t, 3, WithClock(clock), BindContext(ctx), RowLatency(10*time.Second))
+
+	// a 25 second timeout on the fake clock, against rows that take 10 seconds each
+	timeout := clock.After(25 * time.Second)
+	go func() {
+		<-timeout
+		cancel()
+	}()
+
+	next := make(chan bool)
+	for _, step := range []time.Duration{10 * time.Second, 10 * time.Second} {
+		go func() { next <- rs.Next() }()
+		clock.BlockUntil(2)
+		clock.Advance(step)
+		require.True(t, <-next)
+	}
+
+	go func() { next <- rs.Next() }()
+	clock.BlockUntil(2)
+	clock.Advance(5 * time.Second)
+	assert.False(t, <-next)
+	assert.ErrorIs(t, rs.Err(), context.Canceled)
+	assert.Equal(t, start.Add(25*time.Second), clock.Now())
+}
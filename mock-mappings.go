@@ -1,6 +1,8 @@
 package sqlrows
 
 import (
+	"encoding/json"
+	"math"
 	"reflect"
 	"time"
 
@@ -26,35 +28,66 @@ var dbTypeDefaults = map[DatabaseType]map[string]databaseDefaults{
 		"DOUBLE":    {length: 0, precision: 0, scale: 0},
 		"BOOLEAN":   {length: 0, precision: 0, scale: 0},
 		"TIMESTAMP": {length: 0, precision: 0, scale: 0},
+		// gosnowflake reports the timestamp flavor, and semi-structured values as JSON text
+		"TIMESTAMP_NTZ": {length: 0, precision: 0, scale: 0},
+		"TIMESTAMP_LTZ": {length: 0, precision: 0, scale: 0},
+		"TIMESTAMP_TZ":  {length: 0, precision: 0, scale: 0},
+		"VARIANT":       {length: 16777216, precision: 0, scale: 0},
+		"OBJECT":        {length: 16777216, precision: 0, scale: 0},
+		"ARRAY":         {length: 16777216, precision: 0, scale: 0},
+		"BINARY":        {length: 8388608, precision: 0, scale: 0}, // 8 MB max
 	},
 	DbTypePostgresSQL: {
-		"TEXT":                     {length: 1073741824, precision: 0, scale: 0}, // 1 GB max (TEXT has no length limit by default)
-		"VARCHAR":                  {length: 1073741824, precision: 0, scale: 0}, // Same as TEXT if unspecified
-		"NUMERIC":                  {length: 0, precision: 0, scale: 0},          // Unlimited unless specified
-		"DECIMAL":                  {length: 0, precision: 0, scale: 0},          // Alias for NUMERIC
+		"TEXT":                     {length: math.MaxInt64, precision: 0, scale: 0}, // drivers report TEXT as unbounded
+		"VARCHAR":                  {length: 0, precision: 0, scale: 0},             // No length unless specified
+		"NUMERIC":                  {length: 0, precision: 0, scale: 0},             // Unlimited unless specified
+		"DECIMAL":                  {length: 0, precision: 0, scale: 0},             // Alias for NUMERIC
 		"INTEGER":                  {length: 0, precision: 0, scale: 0},
 		"BIGINT":                   {length: 0, precision: 0, scale: 0},
 		"SMALLINT":                 {length: 0, precision: 0, scale: 0},
-		"REAL":                     {length: 0, precision: 6, scale: 0},  // 6 decimal digits
-		"DOUBLE precision":         {length: 0, precision: 15, scale: 0}, // 15 decimal digits
+		"REAL":                     {length: 0, precision: 0, scale: 0}, // drivers report no precision for floats
+		"DOUBLE PRECISION":         {length: 0, precision: 0, scale: 0},
 		"BOOLEAN":                  {length: 0, precision: 0, scale: 0},
 		"TIMESTAMP WITH TIME ZONE": {length: 0, precision: 0, scale: 0},
 		"UUID":                     {length: 0, precision: 0, scale: 0},
+		"JSONB":                    {length: 0, precision: 0, scale: 0},
 	},
 	DbTypeMsSQL: {
-		"NVARCHAR(MAX)":    {length: 2147483647, precision: 0, scale: 0}, // 2^31-1 characters
-		"VARCHAR(MAX)":     {length: 2147483647, precision: 0, scale: 0}, // 2^31-1 bytes
+		"NVARCHAR(MAX)":    {length: 1073741822, precision: 0, scale: 0}, // as go-mssqldb reports it, in characters
+		"VARCHAR(MAX)":     {length: 2147483645, precision: 0, scale: 0}, // as go-mssqldb reports it, in bytes
+		"VARBINARY(MAX)":   {length: 2147483645, precision: 0, scale: 0},
 		"DECIMAL":          {length: 0, precision: 18, scale: 0},
 		"NUMERIC":          {length: 0, precision: 18, scale: 0}, // Alias for DECIMAL
 		"INT":              {length: 0, precision: 0, scale: 0},
 		"BIGINT":           {length: 0, precision: 0, scale: 0},
 		"SMALLINT":         {length: 0, precision: 0, scale: 0},
 		"TINYINT":          {length: 0, precision: 0, scale: 0},
-		"REAL":             {length: 0, precision: 7, scale: 0},  // 7 decimal digits
-		"FLOAT":            {length: 0, precision: 15, scale: 0}, // 15 decimal digits
+		"REAL":             {length: 0, precision: 0, scale: 0}, // go-mssqldb reports no precision for floats
+		"FLOAT":            {length: 0, precision: 0, scale: 0},
 		"BIT":              {length: 0, precision: 0, scale: 0},
 		"DATETIME2":        {length: 0, precision: 0, scale: 0},
 		"UNIQUEIDENTIFIER": {length: 0, precision: 0, scale: 0},
+		"DATE":             {length: 0, precision: 0, scale: 0},
+		"TIME":             {length: 0, precision: 0, scale: 0},
+		"SMALLDATETIME":    {length: 0, precision: 0, scale: 0},
+		"DATETIMEOFFSET":   {length: 0, precision: 0, scale: 0},
+		"MONEY":            {length: 0, precision: 19, scale: 4},
+		"SMALLMONEY":       {length: 0, precision: 10, scale: 4},
+		"CHAR":             {length: 1, precision: 0, scale: 0}, // CHAR without a length holds one
+		"NCHAR":            {length: 1, precision: 0, scale: 0},
+		"TEXT":             {length: 2147483647, precision: 0, scale: 0},
+		"NTEXT":            {length: 1073741823, precision: 0, scale: 0},
+		"IMAGE":            {length: 2147483647, precision: 0, scale: 0},
+		"XML":              {length: 1073741822, precision: 0, scale: 0},
+		"SQL_VARIANT":      {length: 0, precision: 0, scale: 0},
+		"ROWVERSION":       {length: 8, precision: 0, scale: 0},
+	},
+}
+
+// declared types that drivers report under another name
+var reportedTypeNames = map[DatabaseType]map[string]string{
+	DbTypeMsSQL: {
+		"ROWVERSION": "BINARY", // go-mssqldb sees rowversion as BINARY(8)
 	},
 }
 
@@ -81,6 +114,33 @@ var baseTypes = map[string]reflect.Type{
 	"uintptr":    reflect.TypeOf(uintptr(0)),
 	"time.Time":  reflect.TypeOf(time.Time{}),
 	"uuid.UUID":  reflect.TypeOf(uuid.UUID{}),
+
+	"json.RawMessage": reflect.TypeOf(json.RawMessage{}),
+
+	"[]byte":            reflect.TypeOf([]byte(nil)),
+	"[]int64":           reflect.TypeOf([]int64(nil)),
+	"[]float64":         reflect.TypeOf([]float64(nil)),
+	"[]string":          reflect.TypeOf([]string(nil)),
+	"[]bool":            reflect.TypeOf([]bool(nil)),
+	"map[string]string": reflect.TypeOf(map[string]string(nil)),
+	"any":               anyType,
+}
+
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// baseTypeName returns the name a column spec uses for [t], if it is a base type
+func baseTypeName(t reflect.Type) (string, bool) {
+	// reflect names some types by what they alias
+	name := t.String()
+	switch t {
+	case bytesType:
+		name = "[]byte"
+	case baseTypes["json.RawMessage"]:
+		name = "json.RawMessage"
+	case anyType:
+		name = "any"
+	}
+	return name, baseTypes[name] == t
 }
 
 var dbTypesSnowflake = map[string]string{
@@ -100,11 +160,21 @@ var dbTypesSnowflake = map[string]string{
 	"complex64":  "VARCHAR", // No complex type; store as string
 	"complex128": "VARCHAR", // No complex type; store as string
 	"string":     "VARCHAR",
-	"byte":       "INTEGER", // No direct BYTE; INTEGER for 0-255 range
-	"rune":       "INTEGER", // Rune is int32, maps to INTEGER
-	"uintptr":    "BIGINT",  // Pointer size varies, BIGINT is safe
-	"time.Time":  "TIMESTAMP",
-	"uuid.UUID":  "VARCHAR", // Snowflake doesn’t have UUID type; use VARCHAR (36 chars typical)
+	"byte":       "INTEGER",       // No direct BYTE; INTEGER for 0-255 range
+	"rune":       "INTEGER",       // Rune is int32, maps to INTEGER
+	"uintptr":    "BIGINT",        // Pointer size varies, BIGINT is safe
+	"time.Time":  "TIMESTAMP_NTZ", // TIMESTAMP is an alias; gosnowflake reports the flavor
+	"uuid.UUID":  "VARCHAR",       // Snowflake doesn’t have UUID type; use VARCHAR (36 chars typical)
+
+	"json.RawMessage": "VARIANT",
+
+	"[]byte":            "BINARY",
+	"[]int64":           "ARRAY",
+	"[]float64":         "ARRAY",
+	"[]string":          "ARRAY",
+	"[]bool":            "ARRAY",
+	"map[string]string": "OBJECT",
+	"any":               "VARIANT",
 }
 
 var dbTypesPostgres = map[string]string{
@@ -129,6 +199,17 @@ var dbTypesPostgres = map[string]string{
 	"uintptr":    "BIGINT",
 	"time.Time":  "TIMESTAMP WITH TIME ZONE",
 	"uuid.UUID":  "UUID",
+
+	"json.RawMessage": "JSONB",
+
+	// drivers report array types by their element type with a leading underscore
+	"[]byte":            "BYTEA",
+	"[]int64":           "_INT8",
+	"[]float64":         "_FLOAT8",
+	"[]string":          "_TEXT",
+	"[]bool":            "_BOOL",
+	"map[string]string": "HSTORE",
+	"any":               "JSONB", // No variant type; a JSON document holds any value
 }
 
 var dbTypesMsSql = map[string]string{
@@ -153,4 +234,185 @@ var dbTypesMsSql = map[string]string{
 	"uintptr":    "BIGINT",
 	"time.Time":  "DATETIME2",
 	"uuid.UUID":  "UNIQUEIDENTIFIER",
+
+	"json.RawMessage": "NVARCHAR(MAX)", // SQL Server stores JSON as text
+
+	"[]byte":            "VARBINARY(MAX)",
+	"[]int64":           "NVARCHAR(MAX)", // No array type; store as JSON text
+	"[]float64":         "NVARCHAR(MAX)",
+	"[]string":          "NVARCHAR(MAX)",
+	"[]bool":            "NVARCHAR(MAX)",
+	"map[string]string": "NVARCHAR(MAX)",
+	"any":               "SQL_VARIANT",
+}
+
+// SQL type names, including aliases, and the base type names drivers scan them
+// as. Fixed-point types with a fractional scale are resolved in GoTypeFor.
+var goTypesSnowflake = map[string]string{
+	"NUMBER":                         "int64",
+	"DECIMAL":                        "int64",
+	"NUMERIC":                        "int64",
+	"DEC":                            "int64",
+	"INT":                            "int64", // all Snowflake integers are NUMBER(38,0)
+	"INTEGER":                        "int64",
+	"BIGINT":                         "int64",
+	"SMALLINT":                       "int64",
+	"TINYINT":                        "int64",
+	"BYTEINT":                        "int64",
+	"FIXED":                          "int64",
+	"FLOAT":                          "float64",
+	"FLOAT4":                         "float64",
+	"FLOAT8":                         "float64",
+	"DOUBLE":                         "float64",
+	"DOUBLE PRECISION":               "float64",
+	"REAL":                           "float64",
+	"VARCHAR":                        "string",
+	"CHAR":                           "string",
+	"CHARACTER":                      "string",
+	"CHAR VARYING":                   "string",
+	"NCHAR":                          "string",
+	"NCHAR VARYING":                  "string",
+	"NVARCHAR":                       "string",
+	"NVARCHAR2":                      "string",
+	"STRING":                         "string",
+	"TEXT":                           "string",
+	"VARIANT":                        "string", // semi-structured values scan as JSON text
+	"OBJECT":                         "string",
+	"ARRAY":                          "string",
+	"GEOGRAPHY":                      "string",
+	"GEOMETRY":                       "string",
+	"BINARY":                         "[]byte",
+	"VARBINARY":                      "[]byte",
+	"BOOLEAN":                        "bool",
+	"DATE":                           "time.Time",
+	"TIME":                           "time.Time",
+	"DATETIME":                       "time.Time",
+	"TIMESTAMP":                      "time.Time",
+	"TIMESTAMP_NTZ":                  "time.Time",
+	"TIMESTAMP_LTZ":                  "time.Time",
+	"TIMESTAMP_TZ":                   "time.Time",
+	"TIMESTAMP WITHOUT TIME ZONE":    "time.Time",
+	"TIMESTAMP WITH TIME ZONE":       "time.Time",
+	"TIMESTAMP WITH LOCAL TIME ZONE": "time.Time",
+}
+
+var goTypesPostgres = map[string]string{
+	"NUMERIC":                     "int64",
+	"DECIMAL":                     "int64",
+	"SMALLINT":                    "int16",
+	"INT2":                        "int16",
+	"SMALLSERIAL":                 "int16",
+	"SERIAL2":                     "int16",
+	"INTEGER":                     "int32",
+	"INT":                         "int32",
+	"INT4":                        "int32",
+	"SERIAL":                      "int32",
+	"SERIAL4":                     "int32",
+	"BIGINT":                      "int64",
+	"INT8":                        "int64",
+	"BIGSERIAL":                   "int64",
+	"SERIAL8":                     "int64",
+	"REAL":                        "float32",
+	"FLOAT4":                      "float32",
+	"DOUBLE PRECISION":            "float64",
+	"FLOAT8":                      "float64",
+	"FLOAT":                       "float64",
+	"TEXT":                        "string",
+	"VARCHAR":                     "string",
+	"CHARACTER VARYING":           "string",
+	"CHAR":                        "string",
+	"CHARACTER":                   "string",
+	"BPCHAR":                      "string",
+	"NAME":                        "string",
+	"CITEXT":                      "string",
+	"JSON":                        "string",
+	"JSONB":                       "string",
+	"XML":                         "string",
+	"INTERVAL":                    "string",
+	"INET":                        "string",
+	"CIDR":                        "string",
+	"MACADDR":                     "string",
+	"HSTORE":                      "string",
+	"INT4RANGE":                   "string", // ranges arrive in their text form, as in [1,10)
+	"INT8RANGE":                   "string",
+	"NUMRANGE":                    "string",
+	"TSRANGE":                     "string",
+	"TSTZRANGE":                   "string",
+	"DATERANGE":                   "string",
+	"MONEY":                       "string", // lib/pq delivers locale-formatted text, as in -$1,234.50
+	"BYTEA":                       "[]byte",
+	"BOOLEAN":                     "bool",
+	"BOOL":                        "bool",
+	"DATE":                        "time.Time",
+	"TIME":                        "time.Time",
+	"TIMETZ":                      "time.Time",
+	"TIME WITHOUT TIME ZONE":      "time.Time",
+	"TIME WITH TIME ZONE":         "time.Time",
+	"TIMESTAMP":                   "time.Time",
+	"TIMESTAMPTZ":                 "time.Time",
+	"TIMESTAMP WITHOUT TIME ZONE": "time.Time",
+	"TIMESTAMP WITH TIME ZONE":    "time.Time",
+	"UUID":                        "uuid.UUID",
+}
+
+// Postgres catalog names for declared type names, which drivers report for
+// arrays with a leading underscore, as in _INT4 for INTEGER[]
+var pgCatalogNames = map[string]string{
+	"SMALLINT":                    "INT2",
+	"SMALLSERIAL":                 "INT2",
+	"SERIAL2":                     "INT2",
+	"INTEGER":                     "INT4",
+	"INT":                         "INT4",
+	"SERIAL":                      "INT4",
+	"SERIAL4":                     "INT4",
+	"BIGINT":                      "INT8",
+	"BIGSERIAL":                   "INT8",
+	"SERIAL8":                     "INT8",
+	"REAL":                        "FLOAT4",
+	"DOUBLE PRECISION":            "FLOAT8",
+	"FLOAT":                       "FLOAT8",
+	"DECIMAL":                     "NUMERIC",
+	"CHARACTER VARYING":           "VARCHAR",
+	"CHAR":                        "BPCHAR",
+	"CHARACTER":                   "BPCHAR",
+	"BOOLEAN":                     "BOOL",
+	"TIME WITHOUT TIME ZONE":      "TIME",
+	"TIME WITH TIME ZONE":         "TIMETZ",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
+	"TIMESTAMP WITH TIME ZONE":    "TIMESTAMPTZ",
+}
+
+var goTypesMsSql = map[string]string{
+	"DECIMAL":          "int64",
+	"NUMERIC":          "int64",
+	"MONEY":            "int64", // always has a scale of 4
+	"SMALLMONEY":       "int64",
+	"BIT":              "bool",
+	"TINYINT":          "uint8",
+	"SMALLINT":         "int16",
+	"INT":              "int32",
+	"INTEGER":          "int32",
+	"BIGINT":           "int64",
+	"REAL":             "float32",
+	"FLOAT":            "float64",
+	"CHAR":             "string",
+	"VARCHAR":          "string",
+	"NCHAR":            "string",
+	"NVARCHAR":         "string",
+	"TEXT":             "string",
+	"NTEXT":            "string",
+	"XML":              "string",
+	"SYSNAME":          "string",
+	"BINARY":           "[]byte",
+	"VARBINARY":        "[]byte",
+	"IMAGE":            "[]byte",
+	"ROWVERSION":       "[]byte",
+	"SQL_VARIANT":      "any", // the driver delivers the stored value's own type
+	"DATE":             "time.Time",
+	"TIME":             "time.Time",
+	"DATETIME":         "time.Time",
+	"DATETIME2":        "time.Time",
+	"SMALLDATETIME":    "time.Time",
+	"DATETIMEOFFSET":   "time.Time",
+	"UNIQUEIDENTIFIER": "uuid.UUID",
 }
@@ -0,0 +1,135 @@
+package sqlrows
+
+import "math"
+
+// The column metadata each dialect's driver reports. gosnowflake gives a
+// length for text, semi-structured and binary types, and a precision and
+// scale for NUMBER and its integer aliases and for times, whose scale is the
+// fractional digits. lib/pq and pgx derive sizes from the type modifier,
+// report TEXT and BYTEA as unbounded, and leave nullability unknown.
+// go-mssqldb reports lengths in characters for N-types, and a precision and
+// scale only for DECIMAL and NUMERIC.
+
+const (
+	snowflakeTextLength   = 16777216 // 16 MB
+	snowflakeBinaryLength = 8388608  // 8 MB
+	snowflakeTimeScale    = 9
+
+	// lib/pq subtracts the 4 byte header from the type modifier, which is -1
+	// when the column has no declared size
+	postgresNoModifier = -1 - 4
+
+	mssqlMaxLength  = 2147483645 // VARCHAR(MAX) and VARBINARY(MAX), in bytes
+	mssqlNMaxLength = 1073741822 // NVARCHAR(MAX), in characters
+)
+
+func (m *mockColumnType) driverLength() (length int64, ok bool) {
+	decl := driverTypeDecl(m.databaseType)
+	_, hasMax := decl.sizes()
+
+	switch m.dbType {
+	case DbTypeSnowflake:
+		switch {
+		case decl.class() == "text", decl.name == "VARIANT", decl.name == "OBJECT", decl.name == "ARRAY":
+			return orDefault(m.length, snowflakeTextLength), true
+		case decl.name == "BINARY", decl.name == "VARBINARY":
+			return orDefault(m.length, snowflakeBinaryLength), true
+		}
+
+	case DbTypePostgresSQL:
+		switch decl.name {
+		case "TEXT", "BYTEA":
+			return math.MaxInt64, true
+		case "VARCHAR", "CHARACTER VARYING", "CHAR", "CHARACTER", "BPCHAR":
+			if m.length > 0 {
+				return m.length, true
+			}
+			return postgresNoModifier, true
+		}
+
+	case DbTypeMsSQL:
+		// a length is declared as 1 when it is left out
+		switch decl.name {
+		case "NVARCHAR", "NCHAR":
+			if hasMax {
+				return orDefault(m.length, mssqlNMaxLength), true
+			}
+			return orDefault(m.length, 1), true
+		case "VARCHAR", "CHAR", "VARBINARY", "BINARY":
+			if hasMax {
+				return orDefault(m.length, mssqlMaxLength), true
+			}
+			return orDefault(m.length, 1), true
+		case "TEXT", "IMAGE":
+			return math.MaxInt32, true
+		case "NTEXT":
+			return math.MaxInt32 / 2, true
+		case "XML":
+			return mssqlNMaxLength, true
+		}
+	}
+	return 0, false
+}
+
+func (m *mockColumnType) driverDecimalSize() (precision int64, scale int64, ok bool) {
+	decl := driverTypeDecl(m.databaseType)
+	if element, isArray := postgresArrayElement(decl); isArray && m.dbType == DbTypePostgresSQL {
+		decl = sqlTypeDecl{name: element, args: decl.args}
+	}
+
+	// the spec's precision and scale win over the type's arguments
+	precision, scale = m.precision, m.scale
+	numbers, _ := decl.sizes()
+	if precision == 0 && len(numbers) > 0 {
+		precision, scale = numbers[0], append(numbers, 0)[1]
+	}
+
+	switch m.dbType {
+	case DbTypeSnowflake:
+		switch goTypesSnowflake[decl.name] {
+		case "int64":
+			// every Snowflake integer is a NUMBER(38,0)
+			return orDefault(precision, 38), scale, true
+		case "time.Time":
+			if decl.name == "DATE" {
+				break
+			}
+			if len(numbers) > 0 {
+				return 0, numbers[0], true
+			}
+			return 0, orDefault(m.scale, snowflakeTimeScale), true
+		}
+
+	case DbTypePostgresSQL:
+		switch decl.name {
+		case "NUMERIC", "DECIMAL":
+			if precision == 0 {
+				// the missing type modifier decodes to these
+				return postgresNoModifier >> 16 & 0xffff, postgresNoModifier & 0xffff, true
+			}
+			return precision, scale, true
+		}
+
+	case DbTypeMsSQL:
+		switch decl.name {
+		case "DECIMAL", "NUMERIC", "DEC":
+			return orDefault(precision, 18), scale, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (m *mockColumnType) driverNullable() (nullable bool, ok bool) {
+	if m.dbType == DbTypePostgresSQL {
+		// neither lib/pq nor pgx implements ColumnTypeNullable
+		return false, false
+	}
+	return m.nullable, true
+}
+
+func orDefault(n, def int64) int64 {
+	if n == 0 {
+		return def
+	}
+	return n
+}
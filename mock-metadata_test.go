@@ -0,0 +1,119 @@
+package sqlrows
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type metadataCase struct {
+	spec      string
+	length    int64
+	lengthOk  bool
+	precision int64
+	scale     int64
+	decimalOk bool
+}
+
+// verifyDriverMetadata checks each spec's column reports the length and
+// decimal size the dialect's driver does
+func verifyDriverMetadata(t *testing.T, dbType DatabaseType, cases []metadataCase) {
+	specs := make([]string, len(cases))
+	for i, c := range cases {
+		specs[i] = c.spec
+	}
+	colTypes, err := NewMockRowSet(specs, dbType).ColumnTypes()
+	require.NoError(t, err)
+
+	for i, c := range cases {
+		length, ok := colTypes[i].Length()
+		assert.Equal(t, c.length, length, "%s length", c.spec)
+		assert.Equal(t, c.lengthOk, ok, "%s length ok", c.spec)
+
+		precision, scale, ok := colTypes[i].DecimalSize()
+		assert.Equal(t, c.precision, precision, "%s precision", c.spec)
+		assert.Equal(t, c.scale, scale, "%s scale", c.spec)
+		assert.Equal(t, c.decimalOk, ok, "%s decimal size ok", c.spec)
+	}
+}
+
+func TestDriverMetadataSnowflake(t *testing.T) {
+	verifyDriverMetadata(t, DbTypeSnowflake, []metadataCase{
+		{"name=A;type=string", 16777216, true, 0, 0, false},
+		{"name=B;type=string;length=64", 64, true, 0, 0, false},
+		{"name=C;type=string;dbType=TEXT", 16777216, true, 0, 0, false},
+		{"name=D;type=json.RawMessage", 16777216, true, 0, 0, false},
+		{"name=E;type=[]byte", 8388608, true, 0, 0, false},
+		{"name=F;type=int64", 0, false, 38, 0, true},
+		{"name=G;type=int32", 0, false, 38, 0, true},
+		{"name=H;type=float64;dbType=NUMBER(12,2)", 0, false, 12, 2, true},
+		{"name=I;type=float64", 0, false, 0, 0, false},
+		{"name=J;type=bool", 0, false, 0, 0, false},
+		{"name=K;type=time.Time", 0, false, 0, 9, true},
+		{"name=L;type=time.Time;dbType=TIMESTAMP_LTZ(3)", 0, false, 0, 3, true},
+		{"name=M;type=time.Time;dbType=DATE", 0, false, 0, 0, false},
+	})
+
+	colTypes, err := NewMockRowSet([]string{"name=A;type=*string", "name=B;type=int64"}, DbTypeSnowflake).ColumnTypes()
+	require.NoError(t, err)
+	for i, expected := range []bool{true, false} {
+		nullable, ok := colTypes[i].Nullable()
+		assert.Equal(t, expected, nullable)
+		assert.True(t, ok)
+	}
+}
+
+func TestDriverMetadataPostgres(t *testing.T) {
+	verifyDriverMetadata(t, DbTypePostgresSQL, []metadataCase{
+		{"name=a;type=string", math.MaxInt64, true, 0, 0, false},
+		{"name=b;type=[]byte", math.MaxInt64, true, 0, 0, false},
+		{"name=c;type=string;dbType=VARCHAR;length=64", 64, true, 0, 0, false},
+		{"name=d;type=string;dbType=VARCHAR", -5, true, 0, 0, false},
+		{"name=e;type=string;dbType=BPCHAR;length=2", 2, true, 0, 0, false},
+		{"name=f;type=json.RawMessage", 0, false, 0, 0, false},
+		{"name=g;type=int64", 0, false, 0, 0, false},
+		{"name=h;type=float64;dbType=NUMERIC(10,2)", 0, false, 10, 2, true},
+		{"name=i;type=float64;dbType=NUMERIC", 0, false, 65535, 65531, true},
+		{"name=j;type=float64", 0, false, 0, 0, false},
+		{"name=k;type=time.Time", 0, false, 0, 0, false},
+	})
+
+	// neither lib/pq nor pgx reports nullability
+	colTypes, err := NewMockRowSet([]string{"name=a;type=*string", "name=b;type=int64"}, DbTypePostgresSQL).ColumnTypes()
+	require.NoError(t, err)
+	for _, ct := range colTypes {
+		nullable, ok := ct.Nullable()
+		assert.False(t, nullable)
+		assert.False(t, ok)
+	}
+}
+
+func TestDriverMetadataMsSQL(t *testing.T) {
+	verifyDriverMetadata(t, DbTypeMsSQL, []metadataCase{
+		{"name=A;type=string", 1073741822, true, 0, 0, false},
+		{"name=B;type=string;dbType=NVARCHAR;length=50", 50, true, 0, 0, false},
+		{"name=C;type=string;dbType=VARCHAR(MAX)", 2147483645, true, 0, 0, false},
+		{"name=D;type=string;dbType=VARCHAR;length=50", 50, true, 0, 0, false},
+		{"name=E;type=string;dbType=NCHAR", 1, true, 0, 0, false},
+		{"name=F;type=[]byte", 2147483645, true, 0, 0, false},
+		{"name=G;type=string;dbType=NTEXT", 1073741823, true, 0, 0, false},
+		{"name=H;type=string;dbType=XML", 1073741822, true, 0, 0, false},
+		{"name=I;type=int64", 0, false, 0, 0, false},
+		{"name=J;type=float64;dbType=DECIMAL(19,4)", 0, false, 19, 4, true},
+		{"name=K;type=float64;dbType=DECIMAL", 0, false, 18, 0, true},
+		{"name=L;type=uint64", 0, false, 20, 0, true},
+		{"name=M;type=float64;dbType=MONEY", 0, false, 0, 0, false},
+		{"name=N;type=float64", 0, false, 0, 0, false},
+		{"name=O;type=time.Time", 0, false, 0, 0, false},
+	})
+
+	colTypes, err := NewMockRowSet([]string{"name=A;type=*string", "name=B;type=int64"}, DbTypeMsSQL).ColumnTypes()
+	require.NoError(t, err)
+	for i, expected := range []bool{true, false} {
+		nullable, ok := colTypes[i].Nullable()
+		assert.Equal(t, expected, nullable)
+		assert.True(t, ok)
+	}
+}
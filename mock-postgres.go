@@ -0,0 +1,201 @@
+package sqlrows
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// postgresDriverValue renders the Postgres types lib/pq hands back as text
+// without decoding: arrays, hstore, money and intervals
+func postgresDriverValue(v any, decl sqlTypeDecl) (any, bool) {
+	if _, isArray := postgresArrayElement(decl); isArray {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && !isBytesKind(rv.Type()) || rv.Kind() == reflect.Array {
+			return []byte(pgArrayText(rv)), true
+		}
+		return []byte(valueString(v)), true
+	}
+
+	switch decl.name {
+	case "HSTORE":
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Map {
+			return []byte(hstoreText(rv)), true
+		}
+	case "MONEY":
+		if f, ok := numericValue(v); ok {
+			return []byte(pgMoneyText(f)), true
+		}
+	case "INTERVAL":
+		if d, ok := v.(time.Duration); ok {
+			return []byte(pgIntervalText(d)), true
+		}
+	default:
+		return nil, false
+	}
+	return []byte(valueString(v)), true
+}
+
+// postgresArrayElement returns the element type name of an array type, which
+// drivers report with a leading underscore, as in _INT8
+func postgresArrayElement(decl sqlTypeDecl) (string, bool) {
+	if decl.array {
+		return decl.name, true
+	}
+	if name, found := strings.CutPrefix(decl.name, "_"); found && name != "" {
+		return name, true
+	}
+	return "", false
+}
+
+// postgresArrayTypeName returns the name drivers report for an array of
+// [element], as in _INT4 for an array of INTEGER
+func postgresArrayTypeName(element string) string {
+	if name, found := pgCatalogNames[element]; found {
+		element = name
+	}
+	return "_" + element
+}
+
+// pgArrayText renders a slice in the Postgres array literal form, as in {1,2,NULL}
+func pgArrayText(rv reflect.Value) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range rv.Len() {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		elem := rv.Index(i)
+		for elem.Kind() == reflect.Interface || elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+		switch {
+		case (elem.Kind() == reflect.Interface || elem.Kind() == reflect.Pointer) && elem.IsNil():
+			sb.WriteString("NULL")
+		case elem.Kind() == reflect.Slice && !isBytesKind(elem.Type()), elem.Kind() == reflect.Array:
+			sb.WriteString(pgArrayText(elem))
+		default:
+			sb.WriteString(pgArrayElement(pgElementText(elem.Interface())))
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// pgElementText renders a value the way Postgres prints it in a composite literal
+func pgElementText(v any) string {
+	switch tv := v.(type) {
+	case bool:
+		if tv {
+			return "t"
+		}
+		return "f"
+	case []byte:
+		return `\x` + hex.EncodeToString(tv)
+	case time.Time:
+		return tv.Format("2006-01-02 15:04:05.999999-07")
+	}
+	return valueString(v)
+}
+
+// pgArrayElement quotes an array element when Postgres would
+func pgArrayElement(s string) string {
+	if s != "" && !strings.EqualFold(s, "NULL") && !strings.ContainsAny(s, "{}\",\\ \t\n\r\v\f") {
+		return s
+	}
+	return pgQuote(s)
+}
+
+func pgQuote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// hstoreText renders a map in the hstore output form, as in "a"=>"1", "b"=>NULL,
+// with keys in the order Postgres keeps them: shorter keys first
+func hstoreText(rv reflect.Value) string {
+	type pair struct {
+		key, value string
+		null       bool
+	}
+	pairs := make([]pair, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		p := pair{key: valueString(iter.Key().Interface())}
+		if value := normalizeValue(iter.Value().Interface()); value == nil {
+			p.null = true
+		} else {
+			p.value = valueString(value)
+		}
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if len(pairs[i].key) != len(pairs[j].key) {
+			return len(pairs[i].key) < len(pairs[j].key)
+		}
+		return pairs[i].key < pairs[j].key
+	})
+
+	parts := make([]string, len(pairs))
+	for i, p := range pairs {
+		value := "NULL"
+		if !p.null {
+			value = pgQuote(p.value)
+		}
+		parts[i] = pgQuote(p.key) + "=>" + value
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pgMoneyText formats an amount the way Postgres prints MONEY under an en_US
+// lc_monetary, as in -$1,234.50
+func pgMoneyText(f float64) string {
+	cents := int64(math.Round(math.Abs(f) * 100))
+	digits := strconv.FormatInt(cents/100, 10)
+	var sb strings.Builder
+	if f < 0 {
+		sb.WriteByte('-')
+	}
+	sb.WriteByte('$')
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(r)
+	}
+	fmt.Fprintf(&sb, ".%02d", cents%100)
+	return sb.String()
+}
+
+// pgIntervalText formats a duration the way Postgres prints an INTERVAL made
+// from a time span, as in 26:03:04.5
+func pgIntervalText(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	hours := d / time.Hour
+	minutes := d % time.Hour / time.Minute
+	seconds := d % time.Minute / time.Second
+	text := fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+	if micros := d % time.Second / time.Microsecond; micros != 0 {
+		text += strings.TrimRight(fmt.Sprintf(".%06d", micros), "0")
+	}
+	return text
+}
@@ -0,0 +1,101 @@
+package sqlrows
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresTypeNames(t *testing.T) {
+	rs := NewMockRowSet([]string{
+		"name=ids;type=[]int64",
+		"name=tags;type=*[]string",
+		"name=attrs;type=map[string]string",
+		"name=doc;type=json.RawMessage",
+		"name=scan;type=[]byte",
+		"name=mood;type=string;dbType=MOOD",
+	}, DbTypePostgresSQL)
+
+	cts, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	names := make([]string, len(cts))
+	for i, ct := range cts {
+		names[i] = ct.DatabaseTypeName()
+	}
+	assert.Equal(t, []string{"_INT8", "_TEXT", "HSTORE", "JSONB", "BYTEA", "MOOD"}, names)
+}
+
+func TestEmulateDriverPostgresTypes(t *testing.T) {
+	x := "x"
+	rs := NewMockRowSet([]string{
+		"name=ids;type=[]int64",
+		"name=tags;type=*[]string",
+		"name=attrs;type=map[string]string",
+		"name=doc;type=json.RawMessage",
+		"name=scan;type=[]byte",
+		"name=balance;type=float64;dbType=MONEY",
+		"name=wait;type=string;dbType=INTERVAL",
+		"name=during;type=string;dbType=TSRANGE",
+		"name=addr;type=string;dbType=INET",
+		"name=mood;type=string;dbType=MOOD",
+	}, DbTypePostgresSQL, EmulateDriver())
+	rs.AddRow([]any{
+		[]int64{1, 2, 3},
+		[]any{"plain", "two words", `say "hi"`, "", "null", nil},
+		map[string]*string{"b": nil, "aa": &x},
+		map[string]any{"a": 1},
+		[]byte{0xde, 0xad},
+		-1234.5,
+		26*time.Hour + 3*time.Minute + 4*time.Second + 500*time.Millisecond,
+		`["2024-01-01 00:00:00","2024-02-01 00:00:00")`,
+		netip.MustParseAddr("192.168.0.1"),
+		"happy",
+	})
+
+	assert.Equal(t, []any{
+		[]byte("{1,2,3}"),
+		[]byte(`{plain,"two words","say \"hi\"","","null",NULL}`),
+		[]byte(`"b"=>NULL, "aa"=>"x"`),
+		[]byte(`{"a":1}`),
+		[]byte{0xde, 0xad},
+		[]byte("-$1,234.50"),
+		[]byte("26:03:04.5"),
+		[]byte(`["2024-01-01 00:00:00","2024-02-01 00:00:00")`),
+		[]byte("192.168.0.1"),
+		[]byte("happy"),
+	}, scanAny(t, rs))
+}
+
+func TestPgArrayText(t *testing.T) {
+	at := time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC)
+	half := 1.5
+	cases := []struct {
+		v        any
+		expected string
+	}{
+		{[]int64{}, "{}"},
+		{[][]int{{1, 2}, {3, 4}}, "{{1,2},{3,4}}"},
+		{[]bool{true, false}, "{t,f}"},
+		{[]string{`back\slash`, "{brace}", "a,b"}, `{"back\\slash","{brace}","a,b"}`},
+		{[]*float64{nil, &half}, "{NULL,1.5}"},
+		{[]time.Time{at}, `{"2024-06-01 08:30:00+00"}`},
+		{[][]byte{{1, 2}}, `{"\\x0102"}`},
+	}
+	for _, c := range cases {
+		rs := NewMockRowSet([]string{"name=a;type=string;dbType=_TEXT"}, DbTypePostgresSQL, EmulateDriver())
+		rs.AddRow([]any{c.v})
+		assert.Equal(t, []any{[]byte(c.expected)}, scanAny(t, rs), c.expected)
+	}
+}
+
+func TestPgMoneyAndIntervalText(t *testing.T) {
+	assert.Equal(t, "$0.00", pgMoneyText(0))
+	assert.Equal(t, "$999.99", pgMoneyText(999.99))
+	assert.Equal(t, "$1,000,000.01", pgMoneyText(1000000.01))
+	assert.Equal(t, "00:00:00", pgIntervalText(0))
+	assert.Equal(t, "-01:30:00", pgIntervalText(-90*time.Minute))
+	assert.Equal(t, "00:00:00.000001", pgIntervalText(time.Microsecond))
+}
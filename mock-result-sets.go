@@ -0,0 +1,73 @@
+package sqlrows
+
+import "fmt"
+
+type mockResultSets struct {
+	sets  []*mockRowSet
+	index int
+}
+
+// Combines mock row sets into one RowSet that serves them as consecutive result
+// sets, as a batch of queries or a stored procedure does. Each set is read with
+// Next and Scan, then NextResultSet moves on to the following one. The result
+// reads copies of [sets], so the mocks passed in are left as they were.
+func NewMockResultSets(sets ...MockRowSet) RowSet {
+	if len(sets) == 0 {
+		onPanic("at least one result set is required")
+		return nil
+	}
+
+	rs := &mockResultSets{sets: make([]*mockRowSet, 0, len(sets))}
+	for i, set := range sets {
+		mrs, ok := set.(*mockRowSet)
+		if !ok {
+			onPanic(fmt.Sprintf("result set %d is not a mock row set", i))
+			return nil
+		}
+		mrs = mrs.clone()
+		// the mock stays open past its last row while another set follows
+		mrs.hasNextSet = i < len(sets)-1
+		rs.sets = append(rs.sets, mrs)
+	}
+	return rs
+}
+
+func (rs *mockResultSets) current() *mockRowSet {
+	return rs.sets[rs.index]
+}
+
+func (rs *mockResultSets) Close() error {
+	for _, set := range rs.sets {
+		set.Close()
+	}
+	return nil
+}
+
+func (rs *mockResultSets) ColumnTypes() ([]ColumnType, error) {
+	return rs.current().ColumnTypes()
+}
+
+func (rs *mockResultSets) Columns() ([]string, error) {
+	return rs.current().Columns()
+}
+
+func (rs *mockResultSets) Err() error {
+	return rs.current().Err()
+}
+
+func (rs *mockResultSets) Next() bool {
+	return rs.current().Next()
+}
+
+func (rs *mockResultSets) NextResultSet() bool {
+	if rs.current().isClosed() || rs.index == len(rs.sets)-1 {
+		rs.Close()
+		return false
+	}
+	rs.index++
+	return true
+}
+
+func (rs *mockResultSets) Scan(dest ...any) error {
+	return rs.current().Scan(dest...)
+}
@@ -0,0 +1,96 @@
+package sqlrows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockResultSets(t *testing.T) {
+	orders := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake)
+	orders.AddRow([]any{int64(1)})
+	totals := NewMockRowSet([]string{"name=TENANT;type=string", "name=TOTAL;type=float64"}, DbTypeSnowflake)
+	totals.AddRow([]any{"acme", 10.5})
+
+	rs := NewMockResultSets(orders, totals)
+
+	require.True(t, rs.Next())
+	var id int64
+	require.NoError(t, rs.Scan(&id))
+	assert.Equal(t, int64(1), id)
+	assert.False(t, rs.Next())
+
+	// the first set stays open while another follows
+	cols, err := rs.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID"}, cols)
+
+	require.True(t, rs.NextResultSet())
+	cols, err = rs.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TENANT", "TOTAL"}, cols)
+
+	require.True(t, rs.Next())
+	var tenant string
+	var total float64
+	require.NoError(t, rs.Scan(&tenant, &total))
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, 10.5, total)
+	assert.False(t, rs.Next())
+	require.NoError(t, rs.Err())
+
+	assert.False(t, rs.NextResultSet())
+	_, err = rs.Columns()
+	assert.EqualError(t, err, "sql: Rows are closed")
+}
+
+func TestMockResultSetsClose(t *testing.T) {
+	first := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeMsSQL)
+	second := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeMsSQL)
+	rs := NewMockResultSets(first, second)
+
+	require.NoError(t, rs.Close())
+	assert.False(t, rs.NextResultSet())
+	_, err := rs.Columns()
+	assert.EqualError(t, err, "sql: Rows are closed")
+}
+
+func TestMockResultSetsLeavesMocksAlone(t *testing.T) {
+	first := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypePostgresSQL)
+	first.AddRow([]any{int64(1)})
+	second := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypePostgresSQL)
+	second.AddRow([]any{int64(2)})
+
+	rs := NewMockResultSets(first, second)
+	first.AddRow([]any{int64(3)})
+	require.True(t, rs.Next())
+	assert.False(t, rs.Next())
+	require.NoError(t, rs.Close())
+
+	// the mocks passed in still read on their own, and close at their last row
+	var ids []int64
+	for first.Next() {
+		var id int64
+		require.NoError(t, first.Scan(&id))
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []int64{1, 3}, ids)
+	_, err := first.Columns()
+	assert.EqualError(t, err, "sql: Rows are closed")
+
+	require.True(t, second.Next())
+	assert.False(t, second.Next())
+	require.NoError(t, second.Err())
+}
+
+func TestMockResultSetsInvalid(t *testing.T) {
+	it := newTestCommon(t).HooksPanic()
+	NewMockResultSets()
+	it.ExpectedPanic("at least one result set is required")
+
+	it = newTestCommon(t).HooksPanic()
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeMsSQL)
+	NewMockResultSets(rs, struct{ MockRowSet }{rs})
+	it.ExpectedPanic("result set 1 is not a mock row set")
+}
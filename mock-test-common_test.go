@@ -39,8 +39,8 @@ func newTestCommon(t *testing.T) *testCommon {
 	}
 }
 
-func (it *testCommon) HasMockRowSet(cols []string, dbType DatabaseType) *testCommon {
-	it.rs = NewMockRowSet(cols, dbType)
+func (it *testCommon) HasMockRowSet(cols []string, dbType DatabaseType, opts ...MockOption) *testCommon {
+	it.rs = NewMockRowSet(cols, dbType, opts...)
 	return it
 }
 
@@ -89,36 +89,21 @@ func (it *testCommon) VerifiesScan(expectedRows ...[]any) *testCommon {
 	mrs := it.rs.(*mockRowSet)
 	assert.Equal(it.t, it.rowsAdded, len(mrs.values), "Number of rows added does not match")
 
-	mrs.pos = 0
+	it.rs.Rewind()
 	for i := range it.rowsAdded {
 		assert.True(it.t, it.rs.Next(), "Expected more rows to scan")
 		var dest = make([]any, len(mrs.columns))
 		for j := range dest {
-			switch mrs.columnTypes[j].ScanType().Kind() {
-			case reflect.Int:
-				var v int
-				dest[j] = &v
-			case reflect.Int64:
-				var v int64
-				dest[j] = &v
-			case reflect.String:
-				var v string
-				dest[j] = &v
-			case reflect.Ptr:
-				dest[j] = new(time.Time)
-			default:
-				it.t.Fatalf("unsupported type for scan: %v", mrs.columnTypes[j].ScanType())
-			}
+			dest[j] = reflect.New(mrs.columnTypes[j].ScanType()).Interface()
 		}
 		err := it.rs.Scan(dest...)
 		require.NoError(it.t, err)
 		for j, val := range dest {
 			expected := expectedRows[i][j]
-			nullable, _ := mrs.columnTypes[j].Nullable()
-			if nullable {
-				assert.Equal(it.t, expected, val, "Row %d, column %d does not match", i, j)
+			actual := reflect.ValueOf(val).Elem().Interface()
+			if expected == nil {
+				assert.Nil(it.t, actual, "Row %d, column %d does not match", i, j)
 			} else {
-				actual := reflect.ValueOf(val).Elem().Interface()
 				assert.Equal(it.t, expected, actual, "Row %d, column %d does not match", i, j)
 			}
 		}
@@ -153,9 +138,23 @@ func (it *testCommon) VerifiesScanExhausted() *testCommon {
 	assert.False(it.t, it.rs.Next(), "Expected no more rows after scanning")
 	var dummy int
 	err := it.rs.Scan(&dummy)
-	assert.Error(it.t, err, "Expected error when scanning past end")
-	if err != nil {
-		assert.Contains(it.t, err.Error(), "no more rows")
+	assert.EqualError(it.t, err, "sql: Rows are closed", "Expected error when scanning past end")
+	return it
+}
+
+// VerifiesRows drains [rs], checking its columns and normalized row values
+func (it *testCommon) VerifiesRows(rs RowSet, expectedCols []string, expectedRows ...[]any) *testCommon {
+	cols, err := rs.Columns()
+	require.NoError(it.t, err)
+	assert.Equal(it.t, expectedCols, cols, "Columns do not match expected")
+
+	var rows [][]any
+	for rs.Next() {
+		row, err := readRow(rs, len(cols))
+		require.NoError(it.t, err)
+		rows = append(rows, row)
 	}
+	require.NoError(it.t, rs.Err())
+	assert.Equal(it.t, expectedRows, rows, "Rows do not match expected")
 	return it
 }
@@ -0,0 +1,154 @@
+package sqlrows
+
+import "time"
+
+// Sets the session time zone that Snowflake TIMESTAMP_LTZ and Postgres
+// TIMESTAMP WITH TIME ZONE values are reported in. The default is UTC.
+func SessionTimeZone(loc *time.Location) MockOption {
+	return func(cfg *mockConfig) {
+		cfg.sessionZone = loc
+	}
+}
+
+// storedTime applies the storage semantics of a column declared with a time
+// dbType to a time or *time.Time fixture value; other values are returned as is
+func (cfg *mockConfig) storedTime(v any, ct *mockColumnType, dbType DatabaseType) any {
+	decl := driverTypeDecl(ct.databaseType)
+	if !isTimeType(decl, dbType) {
+		return v
+	}
+	switch t := v.(type) {
+	case time.Time:
+		return cfg.driverTime(t, decl, ct, dbType)
+	case *time.Time:
+		if t != nil {
+			stored := cfg.driverTime(*t, decl, ct, dbType)
+			return &stored
+		}
+	}
+	return v
+}
+
+// isTimeType tells whether the dialect's driver scans the type as a time.Time
+func isTimeType(decl sqlTypeDecl, dbType DatabaseType) bool {
+	switch dbType {
+	case DbTypeSnowflake:
+		return goTypesSnowflake[decl.name] == "time.Time"
+	case DbTypePostgresSQL:
+		return goTypesPostgres[decl.name] == "time.Time"
+	case DbTypeMsSQL:
+		return goTypesMsSql[decl.name] == "time.Time"
+	}
+	return false
+}
+
+// driverTime applies the column's storage semantics to a time: fractional
+// seconds are cut to the declared precision, and the zone is kept, normalized or
+// dropped the way the database stores the type
+func (cfg *mockConfig) driverTime(t time.Time, decl sqlTypeDecl, ct *mockColumnType, dbType DatabaseType) time.Time {
+	session := cfg.sessionZone
+	if session == nil {
+		session = time.UTC
+	}
+
+	// the precision comes from the type name, as in DATETIME2(3), or the spec's scale
+	precision := int64(-1)
+	if numbers, _ := decl.sizes(); len(numbers) > 0 {
+		precision = numbers[0]
+	} else if ct.hasScale || ct.scale != 0 {
+		precision = ct.scale
+	}
+
+	switch dbType {
+	case DbTypeSnowflake:
+		switch decl.name {
+		case "DATE":
+			return wallDate(t)
+		case "TIME":
+			return truncateFraction(wallTime(t, 1), precision, 9)
+		case "TIMESTAMP_LTZ", "TIMESTAMP WITH LOCAL TIME ZONE":
+			return truncateFraction(t, precision, 9).In(session)
+		case "TIMESTAMP_TZ", "TIMESTAMP WITH TIME ZONE":
+			return truncateFraction(withFixedOffset(t), precision, 9)
+		}
+		// TIMESTAMP and DATETIME are TIMESTAMP_NTZ under the default type mapping
+		return truncateFraction(wallClock(t), precision, 9)
+
+	case DbTypePostgresSQL:
+		switch decl.name {
+		case "DATE":
+			return wallDate(t)
+		case "TIME", "TIME WITHOUT TIME ZONE":
+			return roundFraction(wallTime(t, 0), precision, 6)
+		case "TIMETZ", "TIME WITH TIME ZONE":
+			return roundFraction(withFixedOffset(wallTime(t, 0)), precision, 6)
+		case "TIMESTAMP", "TIMESTAMP WITHOUT TIME ZONE":
+			return roundFraction(wallClock(t), precision, 6)
+		}
+		return roundFraction(t, precision, 6).In(session)
+
+	case DbTypeMsSQL:
+		switch decl.name {
+		case "DATE":
+			return wallDate(t)
+		case "TIME":
+			return roundFraction(wallTime(t, 1), precision, 7)
+		case "DATETIME":
+			return roundDatetime(wallClock(t))
+		case "SMALLDATETIME":
+			return wallClock(t).Round(time.Minute)
+		case "DATETIMEOFFSET":
+			return roundFraction(withFixedOffset(t), precision, 7)
+		}
+		return roundFraction(wallClock(t), precision, 7)
+	}
+	return t
+}
+
+// wallClock keeps the date and time as written in the value's zone, dropping the zone
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func wallDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// wallTime keeps the time of day on the date the driver uses for TIME values
+func wallTime(t time.Time, year int) time.Time {
+	return time.Date(year, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// withFixedOffset keeps the instant and offset, but not the zone's name or rules
+func withFixedOffset(t time.Time) time.Time {
+	_, offset := t.Zone()
+	return t.In(time.FixedZone("", offset))
+}
+
+// truncateFraction cuts fractional seconds to [precision] digits, or
+// [defaultPrecision] when the precision was not declared
+func truncateFraction(t time.Time, precision, defaultPrecision int64) time.Time {
+	return t.Truncate(fractionUnit(precision, defaultPrecision))
+}
+
+func roundFraction(t time.Time, precision, defaultPrecision int64) time.Time {
+	return t.Round(fractionUnit(precision, defaultPrecision))
+}
+
+func fractionUnit(precision, defaultPrecision int64) time.Duration {
+	if precision < 0 || precision > defaultPrecision {
+		precision = defaultPrecision
+	}
+	unit := time.Nanosecond
+	for range 9 - precision {
+		unit *= 10
+	}
+	return unit
+}
+
+// roundDatetime rounds to the 1/300 second ticks of the SQL Server DATETIME type
+func roundDatetime(t time.Time) time.Time {
+	second := t.Truncate(time.Second)
+	ticks := (int64(t.Sub(second))*300 + int64(time.Second)/2) / int64(time.Second)
+	return second.Add(time.Duration(ticks * int64(time.Second) / 300))
+}
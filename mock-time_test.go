@@ -0,0 +1,119 @@
+package sqlrows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmulateDriverSnowflakeTimestamps(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	rs := NewMockRowSet([]string{
+		"name=NTZ;type=time.Time;dbType=TIMESTAMP_NTZ",
+		"name=LTZ;type=time.Time;dbType=TIMESTAMP_LTZ(3)",
+		"name=TZ;type=time.Time;dbType=TIMESTAMP_TZ",
+		"name=D;type=time.Time;dbType=DATE",
+		"name=T;type=time.Time;dbType=TIME;scale=2",
+	}, DbTypeSnowflake, EmulateDriver(), SessionTimeZone(time.UTC))
+	at := time.Date(2024, 6, 1, 8, 30, 15, 123456789, tokyo)
+	rs.AddRow([]any{at, at, at, at, at})
+
+	vals := scanAny(t, rs)
+	assert.Equal(t, time.Date(2024, 6, 1, 8, 30, 15, 123456789, time.UTC), vals[0])
+	assert.Equal(t, time.Date(2024, 5, 31, 23, 30, 15, 123000000, time.UTC), vals[1])
+	assert.True(t, at.Equal(vals[2].(time.Time)))
+	_, offset := vals[2].(time.Time).Zone()
+	assert.Equal(t, 9*3600, offset)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), vals[3])
+	assert.Equal(t, time.Date(1, 1, 1, 8, 30, 15, 120000000, time.UTC), vals[4])
+}
+
+func TestEmulateDriverPostgresTimestamps(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	rs := NewMockRowSet([]string{
+		"name=created;type=time.Time",
+		"name=local;type=time.Time;dbType=TIMESTAMP",
+		"name=coarse;type=time.Time;dbType=TIMESTAMP(0) WITH TIME ZONE",
+	}, DbTypePostgresSQL, EmulateDriver(), SessionTimeZone(newYork))
+	at := time.Date(2024, 1, 2, 3, 4, 5, 999999600, time.UTC)
+	rs.AddRow([]any{at, at, at})
+
+	vals := scanAny(t, rs)
+	// microseconds, rounded, reported in the session zone
+	assert.Equal(t, time.Date(2024, 1, 1, 22, 4, 6, 0, newYork), vals[0])
+	assert.Equal(t, newYork, vals[0].(time.Time).Location())
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), vals[1])
+	assert.Equal(t, time.Date(2024, 1, 1, 22, 4, 6, 0, newYork), vals[2])
+}
+
+func TestEmulateDriverMsSQLTimestamps(t *testing.T) {
+	pacific := time.FixedZone("PST", -8*3600)
+	rs := NewMockRowSet([]string{
+		"name=Modern;type=time.Time",
+		"name=Legacy;type=time.Time;dbType=DATETIME",
+		"name=Short;type=time.Time;dbType=SMALLDATETIME",
+		"name=Offset;type=time.Time;dbType=DATETIMEOFFSET(3)",
+		"name=Coarse;type=time.Time;dbType=DATETIME2(0)",
+	}, DbTypeMsSQL, EmulateDriver())
+	at := time.Date(2024, 3, 4, 5, 6, 29, 123456789, pacific)
+	rs.AddRow([]any{at, at, at, at, at})
+
+	vals := scanAny(t, rs)
+	assert.Equal(t, time.Date(2024, 3, 4, 5, 6, 29, 123456800, time.UTC), vals[0])
+	assert.Equal(t, time.Date(2024, 3, 4, 5, 6, 29, 123333333, time.UTC), vals[1])
+	assert.Equal(t, time.Date(2024, 3, 4, 5, 6, 0, 0, time.UTC), vals[2])
+	assert.Equal(t, "2024-03-04T05:06:29.123-08:00", vals[3].(time.Time).Format(time.RFC3339Nano))
+	assert.Equal(t, time.Date(2024, 3, 4, 5, 6, 29, 0, time.UTC), vals[4])
+}
+
+func TestMockWithoutEmulationKeepsTimes(t *testing.T) {
+	rs := NewMockRowSet([]string{"name=AT;type=*time.Time"}, DbTypePostgresSQL)
+	at := time.Date(2024, 1, 2, 3, 4, 5, 999999999, time.FixedZone("X", 3600))
+	rs.AddRow([]any{&at})
+	require.True(t, rs.Next())
+	var scanned *time.Time
+	require.NoError(t, rs.Scan(&scanned))
+	require.NotNil(t, scanned)
+	assert.Equal(t, at, *scanned)
+}
+
+func TestDeclaredTimeTypesWithoutEmulation(t *testing.T) {
+	// a declared dbType applies its timestamp semantics without EmulateDriver
+	at := time.Date(2024, 1, 2, 3, 4, 5, 999999999, time.FixedZone("X", 3600))
+	cases := map[DatabaseType]map[string]time.Time{
+		DbTypeSnowflake: {
+			"TIMESTAMP_NTZ(3)": time.Date(2024, 1, 2, 3, 4, 5, 999000000, time.UTC),
+			"TIMESTAMP_LTZ":    time.Date(2024, 1, 2, 2, 4, 5, 999999999, time.UTC),
+			"DATE":             time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		DbTypePostgresSQL: {
+			"TIMESTAMP(0)": time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC),
+			"TIMESTAMPTZ":  time.Date(2024, 1, 2, 2, 4, 6, 0, time.UTC),
+			"TIME":         time.Date(0, 1, 1, 3, 4, 6, 0, time.UTC),
+		},
+		DbTypeMsSQL: {
+			"DATETIME":      time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC),
+			"DATETIME2(2)":  time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC),
+			"SMALLDATETIME": time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC),
+		},
+	}
+	for dbType, types := range cases {
+		for typ, expected := range types {
+			rs := NewMockRowSet([]string{"name=AT;type=time.Time;dbType=" + typ, "name=PTR;type=*time.Time;dbType=" + typ}, dbType, SessionTimeZone(time.UTC))
+			rs.AddRow([]any{at, &at})
+			require.True(t, rs.Next())
+			var value time.Time
+			var ptr *time.Time
+			require.NoError(t, rs.Scan(&value, &ptr))
+			assert.Equal(t, expected, value, "%v %s", dbType, typ)
+			require.NotNil(t, ptr)
+			assert.Equal(t, expected, *ptr, "%v %s", dbType, typ)
+		}
+	}
+
+	// the fixture's own time is not changed
+	assert.Equal(t, 999999999, at.Nanosecond())
+}
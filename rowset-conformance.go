@@ -0,0 +1,481 @@
+package sqlrows
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// ConformanceFixture is the data a RowSetFactory serves as a row set
+	ConformanceFixture struct {
+		DbType     DatabaseType
+		ResultSets []ConformanceResultSet
+	}
+
+	// ConformanceResultSet is one result set of a ConformanceFixture. Values are
+	// the driver-native types int64, float64, bool, string, []byte and
+	// time.Time, or nil.
+	ConformanceResultSet struct {
+		Columns   []string // NewMockRowSet column specs
+		Rows      [][]any
+		FailAfter int   // rows delivered before Err ends the result set
+		Err       error // when set, iteration fails with it
+	}
+
+	// RowSetFactory serves [f] as a RowSet through the implementation under test
+	RowSetFactory func(f ConformanceFixture) (RowSet, error)
+
+	// conformanceT labels each failure with the dialect and scenario it came from
+	conformanceT struct {
+		TestingT
+		scope  string
+		failed bool
+	}
+
+	// conformanceTarget is what scanning a row into a destination type gives:
+	// the value stored from a column, or the error database/sql reports
+	conformanceTarget struct {
+		column int
+		value  any
+		err    string
+	}
+)
+
+var (
+	conformanceColumns = []string{
+		"name=ID;type=int64",
+		"name=NAME;type=string",
+		"name=SCORE;type=float64",
+		"name=ACTIVE;type=bool",
+		"name=CREATED;type=time.Time",
+		"name=PAYLOAD;type=[]byte",
+		"name=NOTE;type=*string",
+		"name=REF;type=string",
+	}
+	conformanceNames = []string{"ID", "NAME", "SCORE", "ACTIVE", "CREATED", "PAYLOAD", "NOTE", "REF"}
+
+	conformanceCreated = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	// sql.Null types, whose value field scans like a plain destination
+	conformanceNullTypes = []reflect.Type{
+		reflect.TypeOf(sql.NullBool{}),
+		reflect.TypeOf(sql.NullByte{}),
+		reflect.TypeOf(sql.NullFloat64{}),
+		reflect.TypeOf(sql.NullInt16{}),
+		reflect.TypeOf(sql.NullInt32{}),
+		reflect.TypeOf(sql.NullInt64{}),
+		reflect.TypeOf(sql.NullString{}),
+		reflect.TypeOf(sql.NullTime{}),
+		reflect.TypeOf(sql.Null[uuid.UUID]{}),
+		reflect.TypeOf(sql.Null[[]byte]{}),
+	}
+
+	errConformance = errors.New("conformance: injected failure")
+)
+
+func conformanceRows() [][]any {
+	return [][]any{
+		{int64(1), "alpha", 1.5, true, conformanceCreated, []byte{1, 2}, "first", "6f1c2a4e-0b9d-4c53-9a57-2f0c8a3e1d11"},
+		{int64(2), "beta", -2.25, false, conformanceCreated.Add(time.Hour), []byte("b"), nil, "0d7e3b52-7c1a-4f8e-8b26-5a9e4c3f2b00"},
+		{int64(3), "gamma", 0.0, true, conformanceCreated.AddDate(0, 0, 1), []byte{0xff}, "third", "b3a91f07-52e4-4d6c-a0f8-19c7e5d2a6b4"},
+	}
+}
+
+// Runs the same scenarios against row sets made by [factory], so that any
+// RowSet implementation can be checked to behave as sql.Rows does: iteration,
+// scanning into every mock column type and the sql.Null types, NULL handling,
+// exhaustion, closing, multiple result sets and errors raised during iteration.
+// Each failure names the dialect and scenario. Returns true when every scenario
+// passed.
+func RunRowSetConformance(t TestingT, factory RowSetFactory) bool {
+	t.Helper()
+
+	passed := true
+	for _, dbType := range []DatabaseType{DbTypeSnowflake, DbTypePostgresSQL, DbTypeMsSQL} {
+		single := ConformanceFixture{
+			DbType:     dbType,
+			ResultSets: []ConformanceResultSet{{Columns: conformanceColumns, Rows: conformanceRows()}},
+		}
+		multi := single
+		multi.ResultSets = append(slices.Clone(single.ResultSets), ConformanceResultSet{
+			Columns: []string{"name=CODE;type=string", "name=TOTAL;type=int64"},
+			Rows:    [][]any{{"x", int64(10)}, {"y", int64(20)}},
+		})
+		failing := single
+		failing.ResultSets = []ConformanceResultSet{{
+			Columns:   conformanceColumns,
+			Rows:      conformanceRows(),
+			FailAfter: 1,
+			Err:       errConformance,
+		}}
+
+		scenarios := []struct {
+			name    string
+			fixture ConformanceFixture
+			run     func(t TestingT, rs RowSet)
+		}{
+			{"columns", single, conformanceColumnsCheck},
+			{"iteration", single, conformanceIteration},
+			{"destinations", single, conformanceDestinations},
+			{"null", single, conformanceNull},
+			{"misuse", single, conformanceMisuse},
+			{"exhaustion", single, conformanceExhaustion},
+			{"close", single, conformanceClose},
+			{"single result set", single, conformanceSingleResultSet},
+			{"result sets", multi, conformanceResultSets},
+			{"error", failing, conformanceFailure},
+		}
+		for _, scenario := range scenarios {
+			ct := &conformanceT{TestingT: t, scope: dbTypeConstants[dbType] + "/" + scenario.name}
+			rs, err := factory(scenario.fixture)
+			if err != nil {
+				ct.Errorf("opening row set: %v", err)
+			} else {
+				scenario.run(ct, rs)
+				rs.Close()
+			}
+			passed = passed && !ct.failed
+		}
+	}
+	return passed
+}
+
+func (t *conformanceT) Errorf(format string, args ...any) {
+	t.TestingT.Helper()
+	t.failed = true
+	t.TestingT.Errorf("%s: %s", t.scope, fmt.Sprintf(format, args...))
+}
+
+func conformanceColumnsCheck(t TestingT, rs RowSet) {
+	t.Helper()
+	cols, err := rs.Columns()
+	if conformanceNoError(t, "Columns", err) {
+		conformanceEqual(t, "Columns", conformanceNames, cols)
+	}
+
+	colTypes, err := rs.ColumnTypes()
+	if !conformanceNoError(t, "ColumnTypes", err) {
+		return
+	}
+	names := make([]string, len(colTypes))
+	for i, ct := range colTypes {
+		names[i] = ct.Name()
+	}
+	conformanceEqual(t, "column type names", conformanceNames, names)
+}
+
+func conformanceIteration(t TestingT, rs RowSet) {
+	t.Helper()
+	var rows [][]any
+	for rs.Next() {
+		row := make([]any, len(conformanceNames))
+		dest := make([]any, len(row))
+		for i := range dest {
+			dest[i] = &row[i]
+		}
+		if !conformanceNoError(t, "Scan", rs.Scan(dest...)) {
+			return
+		}
+		rows = append(rows, row)
+	}
+	conformanceNoError(t, "Err", rs.Err())
+	conformanceEqual(t, "rows scanned into *any", conformanceRows(), rows)
+}
+
+func conformanceDestinations(t TestingT, rs RowSet) {
+	t.Helper()
+	if !rs.Next() {
+		t.Errorf("Next reported no first row: %v", rs.Err())
+		return
+	}
+
+	// conversions between the column types
+	var (
+		idText      string
+		nameBytes   []byte
+		scoreText   string
+		activeText  string
+		createdAny  any
+		payloadText string
+		noteNull    sql.NullString
+		refBytes    []byte
+	)
+	if conformanceNoError(t, "Scan with conversions", rs.Scan(&idText, &nameBytes, &scoreText, &activeText, &createdAny, &payloadText, &noteNull, &refBytes)) {
+		conformanceEqual(t, "converted values",
+			[]any{"1", []byte("alpha"), "1.5", "true", conformanceCreated, "\x01\x02", sql.NullString{String: "first", Valid: true}, []byte(conformanceRows()[0][7].(string))},
+			[]any{idText, nameBytes, scoreText, activeText, createdAny, payloadText, noteNull, refBytes})
+	}
+
+	// every type a mock column can have, so a new base type is covered once added
+	for _, name := range slices.Sorted(maps.Keys(baseTypes)) {
+		typ := baseTypes[name]
+		target, found := conformanceDestination(typ)
+		if !found {
+			t.Errorf("no conformance destination for %s", name)
+			continue
+		}
+		conformanceScanInto(t, rs, typ, target)
+	}
+
+	for _, typ := range conformanceNullTypes {
+		target, found := conformanceDestination(typ.Field(0).Type)
+		if !found || target.err != "" {
+			t.Errorf("no conformance destination for %v", typ)
+			continue
+		}
+		valid := reflect.New(typ).Elem()
+		valid.Field(0).Set(reflect.ValueOf(target.value))
+		valid.FieldByName("Valid").SetBool(true)
+		target.value = valid.Interface()
+		conformanceScanInto(t, rs, typ, target)
+	}
+}
+
+// conformanceDestination chooses the first-row column that scans into [typ], and
+// what database/sql stores or reports for it
+func conformanceDestination(typ reflect.Type) (conformanceTarget, bool) {
+	row := conformanceRows()[0]
+	switch typ {
+	case anyType:
+		return conformanceTarget{column: 0, value: row[0]}, true
+	case reflect.TypeOf(time.Time{}):
+		return conformanceTarget{column: 4, value: row[4]}, true
+	case reflect.TypeOf(uuid.UUID{}):
+		return conformanceTarget{column: 7, value: uuid.MustParse(row[7].(string))}, true
+	}
+
+	convert := func(column int) conformanceTarget {
+		return conformanceTarget{column: column, value: reflect.ValueOf(row[column]).Convert(typ).Interface()}
+	}
+	unsupported := conformanceTarget{
+		column: 1,
+		err:    fmt.Sprintf(`sql: Scan error on column index 1, name "NAME": unsupported Scan, storing driver.Value type string into type *%v`, typ),
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return convert(0), true
+	case reflect.Float32, reflect.Float64:
+		return convert(2), true
+	case reflect.Bool:
+		return convert(3), true
+	case reflect.String:
+		return convert(1), true
+	case reflect.Slice:
+		if isBytesKind(typ) {
+			return convert(5), true
+		}
+		return unsupported, true
+	case reflect.Uintptr, reflect.Complex64, reflect.Complex128, reflect.Map:
+		return unsupported, true
+	}
+	return conformanceTarget{}, false
+}
+
+// conformanceScanInto scans the current row with a [typ] destination for the
+// target column and *any for the others
+func conformanceScanInto(t TestingT, rs RowSet, typ reflect.Type, target conformanceTarget) {
+	t.Helper()
+	dest := make([]any, len(conformanceNames))
+	for i := range dest {
+		dest[i] = new(any)
+	}
+	ptr := reflect.New(typ)
+	dest[target.column] = ptr.Interface()
+
+	err := rs.Scan(dest...)
+	what := fmt.Sprintf("%s into %v", conformanceNames[target.column], typ)
+	if target.err != "" {
+		if err == nil || err.Error() != target.err {
+			t.Errorf("%s:\n  expected error: %s\n  actual:         %v", what, target.err, err)
+		}
+		return
+	}
+	if conformanceNoError(t, "Scan "+what, err) {
+		conformanceEqual(t, what, target.value, ptr.Elem().Interface())
+	}
+}
+
+func conformanceNull(t TestingT, rs RowSet) {
+	t.Helper()
+	rs.Next()
+	if !rs.Next() {
+		t.Errorf("Next reported no second row: %v", rs.Err())
+		return
+	}
+
+	var id int64
+	var name, text, ref string
+	var score float64
+	var active bool
+	var created time.Time
+	var payload []byte
+	var note *string
+	var noteNull sql.NullString
+	var noteAny any = "stale"
+	if conformanceNoError(t, "Scan of NULL", rs.Scan(&id, &name, &score, &active, &created, &payload, &note, &ref)) {
+		conformanceEqual(t, "NULL into **string", (*string)(nil), note)
+	}
+	if conformanceNoError(t, "Scan of NULL", rs.Scan(&id, &name, &score, &active, &created, &payload, &noteNull, &ref)) {
+		conformanceEqual(t, "NULL into sql.NullString", sql.NullString{}, noteNull)
+	}
+	if conformanceNoError(t, "Scan of NULL", rs.Scan(&id, &name, &score, &active, &created, &payload, &noteAny, &ref)) {
+		conformanceEqual(t, "NULL into *any", nil, noteAny)
+	}
+
+	err := rs.Scan(&id, &name, &score, &active, &created, &payload, &text, &ref)
+	conformanceErrorText(t, err, `sql: Scan error on column index 6, name "NOTE": converting NULL to string is unsupported`)
+}
+
+func conformanceMisuse(t TestingT, rs RowSet) {
+	t.Helper()
+	var id int64
+	conformanceErrorText(t, rs.Scan(&id), "sql: Scan called without calling Next")
+
+	if !rs.Next() {
+		t.Errorf("Next reported no first row: %v", rs.Err())
+		return
+	}
+	conformanceErrorText(t, rs.Scan(&id), "sql: expected 8 destination arguments in Scan, not 1")
+
+	var name string
+	conformanceErrorText(t, rs.Scan(&name, &id, &id, &id, &id, &id, &id, &id), `sql: Scan error on column index 1, name "NAME": converting driver.Value type string ("alpha") to a int64: invalid syntax`)
+}
+
+func conformanceExhaustion(t TestingT, rs RowSet) {
+	t.Helper()
+	count := 0
+	for rs.Next() {
+		count++
+	}
+	conformanceEqual(t, "row count", len(conformanceRows()), count)
+	if rs.Next() {
+		t.Errorf("Next reported a row after the rows ran out")
+	}
+	conformanceNoError(t, "Err", rs.Err())
+
+	var id int64
+	conformanceErrorText(t, rs.Scan(&id), "sql: Rows are closed")
+	_, err := rs.Columns()
+	conformanceErrorText(t, err, "sql: Rows are closed")
+	conformanceNoError(t, "Close", rs.Close())
+}
+
+func conformanceClose(t TestingT, rs RowSet) {
+	t.Helper()
+	if !rs.Next() {
+		t.Errorf("Next reported no first row: %v", rs.Err())
+		return
+	}
+	conformanceNoError(t, "Close", rs.Close())
+	conformanceNoError(t, "second Close", rs.Close())
+
+	if rs.Next() {
+		t.Errorf("Next reported a row after Close")
+	}
+	conformanceNoError(t, "Err", rs.Err())
+
+	var id int64
+	conformanceErrorText(t, rs.Scan(&id), "sql: Rows are closed")
+	_, err := rs.Columns()
+	conformanceErrorText(t, err, "sql: Rows are closed")
+	_, err = rs.ColumnTypes()
+	conformanceErrorText(t, err, "sql: Rows are closed")
+}
+
+func conformanceSingleResultSet(t TestingT, rs RowSet) {
+	t.Helper()
+	for rs.Next() {
+	}
+	if rs.NextResultSet() {
+		t.Errorf("NextResultSet reported a second result set for a single query")
+	}
+}
+
+func conformanceResultSets(t TestingT, rs RowSet) {
+	t.Helper()
+	count := 0
+	for rs.Next() {
+		count++
+	}
+	conformanceEqual(t, "first result set row count", len(conformanceRows()), count)
+	if !rs.NextResultSet() {
+		t.Errorf("NextResultSet reported no second result set: %v", rs.Err())
+		return
+	}
+
+	cols, err := rs.Columns()
+	if conformanceNoError(t, "Columns of second result set", err) {
+		conformanceEqual(t, "second result set columns", []string{"CODE", "TOTAL"}, cols)
+	}
+	var codes []string
+	for rs.Next() {
+		var code string
+		var total int64
+		if conformanceNoError(t, "Scan of second result set", rs.Scan(&code, &total)) {
+			codes = append(codes, code)
+		}
+	}
+	conformanceEqual(t, "second result set", []string{"x", "y"}, codes)
+	conformanceNoError(t, "Err", rs.Err())
+
+	if rs.NextResultSet() {
+		t.Errorf("NextResultSet reported a third result set")
+	}
+}
+
+func conformanceFailure(t TestingT, rs RowSet) {
+	t.Helper()
+	if !rs.Next() {
+		t.Errorf("Next reported no row before the injected failure: %v", rs.Err())
+		return
+	}
+	if rs.Next() {
+		t.Errorf("Next reported a row after the injected failure")
+		return
+	}
+	if err := rs.Err(); !errors.Is(err, errConformance) {
+		t.Errorf("Err returned %v, expected the injected failure", err)
+	}
+	if rs.Next() {
+		t.Errorf("Next reported a row after failing")
+	}
+
+	var id int64
+	if err := rs.Scan(&id); !errors.Is(err, errConformance) {
+		t.Errorf("Scan returned %v, expected the injected failure", err)
+	}
+	conformanceNoError(t, "Close", rs.Close())
+}
+
+func conformanceNoError(t TestingT, what string, err error) bool {
+	t.Helper()
+	if err != nil {
+		t.Errorf("%s failed: %v", what, err)
+		return false
+	}
+	return true
+}
+
+func conformanceErrorText(t TestingT, err error, expected string) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("expected the error %q", expected)
+	} else if err.Error() != expected {
+		t.Errorf("error:\n  expected: %s\n  actual:   %s", expected, err)
+	}
+}
+
+func conformanceEqual(t TestingT, what string, expected, actual any) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("%s:\n  expected: %#v\n  actual:   %#v", what, expected, actual)
+	}
+}
@@ -0,0 +1,215 @@
+package sqlrows
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// fixtureConnector is an in-process database/sql driver that serves a
+	// conformance fixture to every query, so sql.Rows can be compared with the mock
+	fixtureConnector struct {
+		fixture ConformanceFixture
+	}
+
+	fixtureConn struct {
+		fixture ConformanceFixture
+	}
+
+	fixtureStmt struct {
+		fixture ConformanceFixture
+	}
+
+	fixtureRows struct {
+		fixture     ConformanceFixture
+		columnTypes [][]*mockColumnType
+		set         int
+		row         int
+	}
+)
+
+func TestRowSetConformance(t *testing.T) {
+	t.Run("mock", func(t *testing.T) {
+		RunRowSetConformance(t, mockConformanceFactory())
+	})
+	t.Run("mock emulating driver", func(t *testing.T) {
+		RunRowSetConformance(t, mockConformanceFactory(EmulateDriver()))
+	})
+	t.Run("database/sql", func(t *testing.T) {
+		RunRowSetConformance(t, sqlConformanceFactory(t))
+	})
+}
+
+func TestRowSetConformanceReportsFailures(t *testing.T) {
+	ft := &fakeT{}
+	assert.False(t, RunRowSetConformance(ft, func(f ConformanceFixture) (RowSet, error) {
+		rs, err := mockConformanceFactory()(f)
+		if err != nil {
+			return nil, err
+		}
+		// a row set that forgets the first row
+		rs.Next()
+		return rs, nil
+	}))
+	assert.Contains(t, ft.messages, "DbTypeSnowflake/iteration: rows scanned into *any:\n"+
+		"  expected: "+fmt.Sprintf("%#v", conformanceRows())+"\n"+
+		"  actual:   "+fmt.Sprintf("%#v", conformanceRows()[1:]))
+
+	ft = &fakeT{}
+	assert.False(t, RunRowSetConformance(ft, func(ConformanceFixture) (RowSet, error) {
+		return nil, errors.New("no connection")
+	}))
+	assert.Len(t, ft.messages, 30)
+	assert.Equal(t, "DbTypeMsSQL/error: opening row set: no connection", ft.messages[29])
+}
+
+func mockConformanceFactory(opts ...MockOption) RowSetFactory {
+	return func(f ConformanceFixture) (RowSet, error) {
+		sets := make([]MockRowSet, 0, len(f.ResultSets))
+		for _, set := range f.ResultSets {
+			setOpts := opts
+			if set.Err != nil {
+				setOpts = append(slices.Clone(opts), FailAfter(set.FailAfter, set.Err))
+			}
+			mrs := NewMockRowSet(set.Columns, f.DbType, setOpts...)
+			for _, row := range set.Rows {
+				mrs.AddRow(row)
+			}
+			sets = append(sets, mrs)
+		}
+		if len(sets) == 1 {
+			return sets[0], nil
+		}
+		return NewMockResultSets(sets...), nil
+	}
+}
+
+func sqlConformanceFactory(t *testing.T) RowSetFactory {
+	return func(f ConformanceFixture) (RowSet, error) {
+		db := sql.OpenDB(&fixtureConnector{fixture: f})
+		t.Cleanup(func() { db.Close() })
+
+		rows, err := db.Query("SELECT fixture")
+		if err != nil {
+			return nil, err
+		}
+		return NewRowSet(rows), nil
+	}
+}
+
+func (c *fixtureConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fixtureConn{fixture: c.fixture}, nil
+}
+
+func (c *fixtureConnector) Driver() driver.Driver {
+	return c
+}
+
+func (c *fixtureConnector) Open(string) (driver.Conn, error) {
+	return c.Connect(context.Background())
+}
+
+func (c *fixtureConn) Prepare(string) (driver.Stmt, error) {
+	return &fixtureStmt{fixture: c.fixture}, nil
+}
+
+func (c *fixtureConn) Close() error {
+	return nil
+}
+
+func (c *fixtureConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (s *fixtureStmt) Close() error {
+	return nil
+}
+
+func (s *fixtureStmt) NumInput() int {
+	return 0
+}
+
+func (s *fixtureStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("statements are not supported")
+}
+
+func (s *fixtureStmt) Query([]driver.Value) (driver.Rows, error) {
+	rows := &fixtureRows{fixture: s.fixture}
+	for _, set := range s.fixture.ResultSets {
+		mrs := NewMockRowSet(set.Columns, s.fixture.DbType).(*mockRowSet)
+		rows.columnTypes = append(rows.columnTypes, mrs.columnTypes)
+	}
+	return rows, nil
+}
+
+func (r *fixtureRows) Columns() []string {
+	names := make([]string, len(r.columnTypes[r.set]))
+	for i, ct := range r.columnTypes[r.set] {
+		names[i] = ct.Name()
+	}
+	return names
+}
+
+func (r *fixtureRows) Close() error {
+	return nil
+}
+
+func (r *fixtureRows) Next(dest []driver.Value) (err error) {
+	set := r.fixture.ResultSets[r.set]
+	if set.Err != nil && r.row == set.FailAfter {
+		return set.Err
+	}
+	if r.row == len(set.Rows) {
+		return io.EOF
+	}
+
+	for i, v := range set.Rows[r.row] {
+		if dest[i], err = driver.DefaultParameterConverter.ConvertValue(v); err != nil {
+			return err
+		}
+	}
+	r.row++
+	return nil
+}
+
+func (r *fixtureRows) HasNextResultSet() bool {
+	return r.set < len(r.fixture.ResultSets)-1
+}
+
+func (r *fixtureRows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.set++
+	r.row = 0
+	return nil
+}
+
+func (r *fixtureRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.columnTypes[r.set][index].DatabaseTypeName()
+}
+
+func (r *fixtureRows) ColumnTypeLength(index int) (length int64, ok bool) {
+	return r.columnTypes[r.set][index].Length()
+}
+
+func (r *fixtureRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return r.columnTypes[r.set][index].Nullable()
+}
+
+func (r *fixtureRows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	return r.columnTypes[r.set][index].DecimalSize()
+}
+
+func (r *fixtureRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.columnTypes[r.set][index].ScanType()
+}
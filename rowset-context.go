@@ -0,0 +1,82 @@
+package sqlrows
+
+import "context"
+
+type contextRowSet struct {
+	RowSet
+	ctx      context.Context
+	stop     func() bool
+	finished bool
+}
+
+// Returns [rs] bound to [ctx]: when [ctx] is cancelled or its deadline passes,
+// [rs] is closed right away, even while a Next call is waiting on the database,
+// so a long stream stops promptly. Next then reports false, and Err and Scan
+// return ctx.Err(). [rs] must allow Close while Next is running, as sql.Rows
+// does. Closing the result releases [ctx].
+func WithContext(ctx context.Context, rs RowSet) RowSet {
+	crs := &contextRowSet{RowSet: rs, ctx: ctx}
+	crs.stop = context.AfterFunc(ctx, func() { rs.Close() })
+	return crs
+}
+
+// ctxErr is the context's error once it has cut iteration short
+func (crs *contextRowSet) ctxErr() error {
+	if crs.finished {
+		return nil
+	}
+	return crs.ctx.Err()
+}
+
+func (crs *contextRowSet) Close() error {
+	crs.stop()
+	return crs.RowSet.Close()
+}
+
+func (crs *contextRowSet) ColumnTypes() ([]ColumnType, error) {
+	if err := crs.ctxErr(); err != nil {
+		return nil, err
+	}
+	return crs.RowSet.ColumnTypes()
+}
+
+func (crs *contextRowSet) Columns() ([]string, error) {
+	if err := crs.ctxErr(); err != nil {
+		return nil, err
+	}
+	return crs.RowSet.Columns()
+}
+
+func (crs *contextRowSet) Err() error {
+	if err := crs.ctxErr(); err != nil {
+		return err
+	}
+	return crs.RowSet.Err()
+}
+
+func (crs *contextRowSet) Next() bool {
+	if crs.finished || crs.ctx.Err() != nil {
+		return false
+	}
+	if crs.RowSet.Next() {
+		return true
+	}
+	// rows that ran out before the cancellation keep their own outcome
+	crs.finished = crs.ctx.Err() == nil
+	return false
+}
+
+func (crs *contextRowSet) NextResultSet() bool {
+	if crs.ctx.Err() != nil || !crs.RowSet.NextResultSet() {
+		return false
+	}
+	crs.finished = false
+	return true
+}
+
+func (crs *contextRowSet) Scan(dest ...any) error {
+	if err := crs.ctxErr(); err != nil {
+		return err
+	}
+	return crs.RowSet.Scan(dest...)
+}
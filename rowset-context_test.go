@@ -0,0 +1,132 @@
+package sqlrows
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingRowSet delivers a row each time one is sent on rows, and blocks in
+// Next until then, as a driver waiting on the network does
+type blockingRowSet struct {
+	RowSet
+	rows   chan struct{}
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newBlockingRowSet() *blockingRowSet {
+	return &blockingRowSet{
+		RowSet: NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake),
+		rows:   make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+}
+
+func (b *blockingRowSet) Next() bool {
+	select {
+	case _, ok := <-b.rows:
+		return ok
+	case <-b.closed:
+		return false
+	}
+}
+
+func (b *blockingRowSet) Err() error {
+	return nil
+}
+
+func (b *blockingRowSet) Close() error {
+	b.once.Do(func() { close(b.closed) })
+	return nil
+}
+
+func TestWithContextCancelWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inner := newBlockingRowSet()
+	rs := WithContext(ctx, inner)
+
+	next := make(chan bool)
+	go func() { next <- rs.Next() }()
+	inner.rows <- struct{}{}
+	require.True(t, <-next)
+
+	go func() { next <- rs.Next() }()
+	cancel()
+	select {
+	case more := <-next:
+		assert.False(t, more)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Next did not return after the context was cancelled")
+	}
+
+	assert.ErrorIs(t, rs.Err(), context.Canceled)
+	var id int64
+	assert.ErrorIs(t, rs.Scan(&id), context.Canceled)
+	assert.False(t, rs.Next())
+	assert.NoError(t, rs.Close())
+}
+
+func TestWithContextRowsEndFirst(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inner := newBlockingRowSet()
+	rs := WithContext(ctx, inner)
+
+	close(inner.rows)
+	assert.False(t, rs.Next())
+	cancel()
+
+	assert.NoError(t, rs.Err())
+	assert.NoError(t, rs.Close())
+}
+
+func TestWithContextCloseReleasesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tracker := &closeTracker{RowSet: NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeMsSQL)}
+	rs := WithContext(ctx, tracker)
+	require.NoError(t, rs.Close())
+	assert.True(t, tracker.closed)
+
+	// once closed, cancelling the context leaves the row set alone
+	tracker.closed = false
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	assert.False(t, tracker.closed)
+}
+
+// run with -race: the context's AfterFunc closes the mock from another
+// goroutine while this one is iterating
+func TestWithContextCancelDuringMockIteration(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock := NewGeneratedRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, 1_000_000,
+		func(i int) []any { return []any{int64(i)} }, RowLatency(time.Microsecond))
+	rs := WithContext(ctx, mock)
+
+	rows := 0
+	for rs.Next() {
+		var id int64
+		if err := rs.Scan(&id); err != nil {
+			assert.ErrorIs(t, err, context.Canceled)
+			break
+		}
+		rows++
+		if rows == 100 {
+			go cancel()
+		}
+	}
+
+	assert.GreaterOrEqual(t, rows, 100)
+	assert.Less(t, rows, 1_000_000)
+	assert.ErrorIs(t, rs.Err(), context.Canceled)
+	assert.NoError(t, rs.Close())
+}
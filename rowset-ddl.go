@@ -0,0 +1,415 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	ddlToken struct {
+		text   string
+		quoted bool // a quoted identifier or string literal, never a keyword
+	}
+
+	ddlColumn struct {
+		name    string
+		decl    sqlTypeDecl
+		notNull bool
+	}
+)
+
+// words that end a column's type and start its constraints
+var ddlConstraintWords = map[string]struct{}{
+	"NOT": {}, "NULL": {}, "DEFAULT": {}, "PRIMARY": {}, "UNIQUE": {}, "REFERENCES": {},
+	"CHECK": {}, "CONSTRAINT": {}, "COLLATE": {}, "IDENTITY": {}, "AUTOINCREMENT": {},
+	"AUTO_INCREMENT": {}, "GENERATED": {}, "COMMENT": {}, "MASKING": {}, "TAG": {},
+	"AS": {}, "SPARSE": {}, "ROWGUIDCOL": {}, "FILESTREAM": {}, "ENCODE": {}, "WITH": {},
+}
+
+// reserved words that start a table constraint rather than a column
+var ddlTableConstraints = map[string]struct{}{
+	"CONSTRAINT": {}, "PRIMARY": {}, "UNIQUE": {}, "FOREIGN": {}, "CHECK": {}, "LIKE": {},
+}
+
+// Parses a CREATE TABLE statement in the [dbType] dialect into NewMockRowSet
+// column specs. SQL types map to the Go types the drivers scan; a column is
+// nullable unless declared NOT NULL or part of the primary key. Unquoted names
+// are folded the way the database folds them. When [columns] are given, only
+// those columns are returned, in that order.
+func ParseCreateTable(ddl string, dbType DatabaseType, columns ...string) ([]string, error) {
+	if dbTypeDefaults[dbType] == nil {
+		return nil, errors.New("invalid database type")
+	}
+
+	tableName, parsed, err := parseDDL(ddl, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CREATE TABLE: %w", err)
+	}
+
+	if len(columns) > 0 {
+		byName := make(map[string]ddlColumn, len(parsed))
+		for _, col := range parsed {
+			byName[strings.ToLower(col.name)] = col
+		}
+		projected := make([]ddlColumn, 0, len(columns))
+		for _, name := range columns {
+			col, found := byName[strings.ToLower(name)]
+			if !found {
+				return nil, fmt.Errorf("column %s is not in table %s", name, tableName)
+			}
+			projected = append(projected, col)
+		}
+		parsed = projected
+	}
+
+	specs := make([]string, 0, len(parsed))
+	for _, col := range parsed {
+		spec, err := col.spec(dbType)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", col.name, err)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// parseDDL finds the table name and column definitions of a CREATE TABLE statement
+func parseDDL(ddl string, dbType DatabaseType) (string, []ddlColumn, error) {
+	tokens, err := tokenizeDDL(ddl)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pos := 0
+	keyword := func(words ...string) bool {
+		for i, word := range words {
+			if pos+i >= len(tokens) || tokens[pos+i].quoted || !strings.EqualFold(tokens[pos+i].text, word) {
+				return false
+			}
+		}
+		pos += len(words)
+		return true
+	}
+
+	if !keyword("CREATE") {
+		return "", nil, errors.New("statement does not start with CREATE")
+	}
+	keyword("OR", "REPLACE")
+	for pos < len(tokens) && !tokens[pos].quoted && !strings.EqualFold(tokens[pos].text, "TABLE") {
+		// TEMPORARY, TRANSIENT, UNLOGGED and similar modifiers
+		pos++
+	}
+	if !keyword("TABLE") {
+		return "", nil, errors.New("statement is not CREATE TABLE")
+	}
+	keyword("IF", "NOT", "EXISTS")
+
+	// the table name may be qualified with a schema and database
+	var nameParts []string
+	for pos < len(tokens) && tokens[pos].text != "(" {
+		if tokens[pos].text != "." {
+			nameParts = append(nameParts, foldIdentifier(tokens[pos], dbType))
+		}
+		pos++
+	}
+	if pos == len(tokens) || len(nameParts) == 0 {
+		return "", nil, errors.New("missing column list")
+	}
+	tableName := strings.Join(nameParts, ".")
+	pos++
+
+	// split the column list at top level commas
+	var elements [][]ddlToken
+	var element []ddlToken
+	depth := 0
+	for ; pos < len(tokens); pos++ {
+		tok := tokens[pos]
+		if !tok.quoted {
+			switch tok.text {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				depth--
+			}
+		}
+		if depth < 0 {
+			break
+		}
+		if depth == 0 && !tok.quoted && tok.text == "," {
+			elements = append(elements, element)
+			element = nil
+			continue
+		}
+		element = append(element, tok)
+	}
+	if depth >= 0 {
+		return "", nil, errors.New("column list is not closed")
+	}
+	elements = append(elements, element)
+
+	var columns []ddlColumn
+	primaryKey := map[string]struct{}{}
+	for _, element := range elements {
+		if len(element) == 0 {
+			continue
+		}
+		if isTableConstraint(element) {
+			for _, name := range primaryKeyColumns(element) {
+				primaryKey[strings.ToLower(foldIdentifier(name, dbType))] = struct{}{}
+			}
+			continue
+		}
+		col, err := parseDDLColumn(element, dbType)
+		if err != nil {
+			return "", nil, err
+		}
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return "", nil, errors.New("table has no columns")
+	}
+
+	for i := range columns {
+		if _, found := primaryKey[strings.ToLower(columns[i].name)]; found {
+			columns[i].notNull = true
+		}
+	}
+	return tableName, columns, nil
+}
+
+// isTableConstraint tells a table constraint or inline index from a column
+// definition; INDEX and EXCLUDE are not reserved, so they can also be column names
+func isTableConstraint(element []ddlToken) bool {
+	if element[0].quoted {
+		return false
+	}
+	word := strings.ToUpper(element[0].text)
+	if _, found := ddlTableConstraints[word]; found {
+		return true
+	}
+	if (word == "INDEX" || word == "EXCLUDE") && len(element) > 2 {
+		next := strings.ToUpper(element[1].text)
+		return next == "USING" || next == "(" || element[2].text == "(" ||
+			strings.HasSuffix(strings.ToUpper(element[2].text), "CLUSTERED")
+	}
+	return false
+}
+
+// parseDDLColumn reads a column definition: the name, the type and its constraints
+func parseDDLColumn(element []ddlToken, dbType DatabaseType) (col ddlColumn, err error) {
+	col.name = foldIdentifier(element[0], dbType)
+	pos := 1
+
+	col.decl, pos, err = parseSQLType(element, pos)
+	if err != nil {
+		return col, fmt.Errorf("column %s has an %w", col.name, err)
+	}
+	if col.decl.name == "" {
+		return col, fmt.Errorf("column %s has no type", col.name)
+	}
+	for ; pos < len(element); pos++ {
+		tok := element[pos]
+		switch {
+		case tok.quoted:
+		case tok.text == "(":
+			pos = closingParen(element, pos)
+		case strings.EqualFold(tok.text, "NOT") && pos+1 < len(element) && strings.EqualFold(element[pos+1].text, "NULL"):
+			col.notNull = true
+			pos++
+		case strings.EqualFold(tok.text, "PRIMARY"):
+			col.notNull = true
+		}
+	}
+
+	// serial types are integers with a sequence default, and never NULL
+	if dbType == DbTypePostgresSQL && strings.HasSuffix(col.decl.name, "SERIAL") {
+		col.notNull = true
+	}
+	return col, nil
+}
+
+// primaryKeyColumns lists the columns of a PRIMARY KEY table constraint
+func primaryKeyColumns(element []ddlToken) []ddlToken {
+	for pos := 0; pos+2 < len(element); pos++ {
+		if !element[pos].quoted && strings.EqualFold(element[pos].text, "PRIMARY") &&
+			strings.EqualFold(element[pos+1].text, "KEY") {
+			for pos += 2; pos < len(element) && element[pos].text != "("; pos++ {
+				// CLUSTERED and similar options
+			}
+			if pos == len(element) {
+				return nil
+			}
+			var names []ddlToken
+			for _, tok := range element[pos+1 : closingParen(element, pos)] {
+				if tok.text != "," && (tok.quoted || !isSortWord(tok.text)) {
+					names = append(names, tok)
+				}
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+func isSortWord(text string) bool {
+	switch strings.ToUpper(text) {
+	case "ASC", "DESC":
+		return true
+	}
+	return false
+}
+
+// closingParen finds the token that closes the bracket at [open]
+func closingParen(tokens []ddlToken, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].quoted {
+			continue
+		}
+		switch tokens[i].text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// foldIdentifier applies the database's case rules to an unquoted identifier
+func foldIdentifier(tok ddlToken, dbType DatabaseType) string {
+	if tok.quoted {
+		return tok.text
+	}
+	switch dbType {
+	case DbTypeSnowflake:
+		return strings.ToUpper(tok.text)
+	case DbTypePostgresSQL:
+		return strings.ToLower(tok.text)
+	}
+	return tok.text
+}
+
+func isDDLWord(text string) bool {
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' {
+			return false
+		}
+	}
+	return text != ""
+}
+
+// tokenizeDDL splits a statement into words, numbers, punctuation, quoted
+// identifiers and string literals, dropping comments
+func tokenizeDDL(ddl string) ([]ddlToken, error) {
+	var tokens []ddlToken
+	runes := []rune(ddl)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && (runes[j] != '*' || runes[j+1] != '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, errors.New("comment is not closed")
+			}
+			i = j + 2
+		case r == '"' || r == '`' || r == '\'' || r == '[' && i+1 < len(runes) && isIdentStart(runes[i+1]):
+			closer := r
+			if r == '[' {
+				closer = ']'
+			}
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == closer {
+					// a doubled closer is an escaped one
+					if j+1 < len(runes) && runes[j+1] == closer {
+						sb.WriteRune(closer)
+						j++
+						continue
+					}
+					break
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("%c is not closed", r)
+			}
+			tokens = append(tokens, ddlToken{text: sb.String(), quoted: true})
+			i = j + 1
+		case isDDLWord(string(r)):
+			j := i
+			for j < len(runes) && (isDDLWord(string(runes[j])) || runes[j] == '.' && j > i && unicode.IsDigit(runes[i])) {
+				j++
+			}
+			tokens = append(tokens, ddlToken{text: string(runes[i:j])})
+			i = j
+		default:
+			tokens = append(tokens, ddlToken{text: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+// spec renders the column as a NewMockRowSet column spec
+func (col *ddlColumn) spec(dbType DatabaseType) (string, error) {
+	goType, err := col.decl.goType(dbType, newTypeConfig(nil))
+	if err != nil {
+		return "", err
+	}
+	typeName, found := baseTypeName(goType)
+	if !found {
+		return "", fmt.Errorf("no mock type for %v", goType)
+	}
+	if !col.notNull {
+		typeName = "*" + typeName
+	}
+
+	dbColType := col.decl.name
+	if col.decl.array {
+		// drivers report the array type by its element's catalog name
+		dbColType = postgresArrayTypeName(dbColType)
+	}
+	var sizes []string
+	numbers, hasMax := col.decl.sizes()
+	switch col.decl.class() {
+	case "text":
+		if hasMax {
+			dbColType += "(MAX)"
+		} else if len(numbers) > 0 {
+			sizes = append(sizes, fmt.Sprintf("length=%d", numbers[0]))
+		}
+	case "decimal":
+		if len(numbers) > 0 {
+			// an omitted scale is zero
+			sizes = append(sizes, fmt.Sprintf("precision=%d", numbers[0]), fmt.Sprintf("scale=%d", append(numbers, 0)[1]))
+		}
+	default:
+		// a time's argument is its fractional seconds precision, which specs carry as the scale
+		if goType == baseTypes["time.Time"] && len(numbers) > 0 {
+			sizes = append(sizes, fmt.Sprintf("scale=%d", numbers[0]))
+		}
+	}
+
+	parts := append([]string{"name=" + col.name, "type=" + typeName, "dbType=" + dbColType}, sizes...)
+	return strings.Join(parts, ";"), nil
+}
@@ -0,0 +1,173 @@
+package sqlrows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateTableSnowflake(t *testing.T) {
+	specs, err := ParseCreateTable(`
+		-- orders placed through the storefront
+		CREATE OR REPLACE TRANSIENT TABLE analytics.public.orders (
+			id NUMBER(38,0) NOT NULL,
+			"tenant" VARCHAR(64) NOT NULL COMMENT 'owning tenant, see /* docs */',
+			amount NUMBER(12, 2),
+			ratio FLOAT,
+			shipped_at TIMESTAMP_NTZ,
+			payload VARIANT,
+			active BOOLEAN DEFAULT TRUE,
+			PRIMARY KEY (id)
+		);`, DbTypeSnowflake)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name=ID;type=int64;dbType=NUMBER;precision=38;scale=0",
+		"name=tenant;type=string;dbType=VARCHAR;length=64",
+		"name=AMOUNT;type=*float64;dbType=NUMBER;precision=12;scale=2",
+		"name=RATIO;type=*float64;dbType=FLOAT",
+		"name=SHIPPED_AT;type=*time.Time;dbType=TIMESTAMP_NTZ",
+		"name=PAYLOAD;type=*string;dbType=VARIANT",
+		"name=ACTIVE;type=*bool;dbType=BOOLEAN",
+	}, specs)
+
+	// the specs build a mock with the same metadata
+	rs := NewMockRowSet(specs, DbTypeSnowflake)
+	assert.True(t, ExpectSchema(t, rs,
+		"name=ID;type=int64;precision=38;scale=0",
+		"name=tenant;length=64",
+		"name=AMOUNT;precision=12;scale=2",
+		"name=RATIO", "name=SHIPPED_AT", "name=PAYLOAD", "name=ACTIVE",
+	))
+}
+
+func TestParseCreateTablePostgres(t *testing.T) {
+	specs, err := ParseCreateTable(`
+		CREATE TABLE IF NOT EXISTS "Billing".invoices (
+			invoice_id BIGSERIAL,
+			Customer UUID NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+			total NUMERIC,
+			quantity INT4 CHECK (quantity > 0),
+			issued TIMESTAMP(3) WITH TIME ZONE NOT NULL DEFAULT now(),
+			memo CHARACTER VARYING(200),
+			"index" SMALLINT,
+			tags TEXT[],
+			scores INT8[] NOT NULL,
+			lines INTEGER[] NOT NULL,
+			refs UUID ARRAY,
+			fee MONEY,
+			scan BYTEA,
+			CONSTRAINT invoices_pk PRIMARY KEY (invoice_id)
+		)`, DbTypePostgresSQL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name=invoice_id;type=int64;dbType=BIGSERIAL",
+		"name=customer;type=uuid.UUID;dbType=UUID",
+		"name=total;type=*float64;dbType=NUMERIC",
+		"name=quantity;type=*int32;dbType=INT4",
+		"name=issued;type=time.Time;dbType=TIMESTAMP WITH TIME ZONE;scale=3",
+		"name=memo;type=*string;dbType=CHARACTER VARYING;length=200",
+		"name=index;type=*int16;dbType=SMALLINT",
+		"name=tags;type=*[]string;dbType=_TEXT",
+		"name=scores;type=[]int64;dbType=_INT8",
+		"name=lines;type=[]int64;dbType=_INT4",
+		"name=refs;type=*[]string;dbType=_UUID",
+		"name=fee;type=*string;dbType=MONEY",
+		"name=scan;type=*[]byte;dbType=BYTEA",
+	}, specs)
+}
+
+func TestParseCreateTableMsSQL(t *testing.T) {
+	specs, err := ParseCreateTable(`
+		CREATE TABLE [dbo].[Accounts] (
+			[AccountId] INT IDENTITY(1,1) NOT NULL,
+			[Name] NVARCHAR(MAX) NULL,
+			[Code] NCHAR(4) NOT NULL,
+			[Balance] DECIMAL(19, 4) NOT NULL,
+			[Rate] FLOAT(24),
+			[Opened] DATETIME2(7),
+			[Key] UNIQUEIDENTIFIER ROWGUIDCOL,
+			CONSTRAINT [PK_Accounts] PRIMARY KEY CLUSTERED ([AccountId] ASC),
+			INDEX IX_Accounts_Code NONCLUSTERED ([Code])
+		)`, DbTypeMsSQL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name=AccountId;type=int32;dbType=INT",
+		"name=Name;type=*string;dbType=NVARCHAR(MAX)",
+		"name=Code;type=string;dbType=NCHAR;length=4",
+		"name=Balance;type=float64;dbType=DECIMAL;precision=19;scale=4",
+		"name=Rate;type=*float32;dbType=FLOAT",
+		"name=Opened;type=*time.Time;dbType=DATETIME2;scale=7",
+		"name=Key;type=*uuid.UUID;dbType=UNIQUEIDENTIFIER",
+	}, specs)
+}
+
+func TestParseCreateTableTimePrecision(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	cases := []struct {
+		dbType   DatabaseType
+		ddl      string
+		spec     string
+		expected time.Time
+	}{
+		{DbTypeSnowflake, "CREATE TABLE t (at TIMESTAMP_NTZ(3) NOT NULL)",
+			"name=AT;type=time.Time;dbType=TIMESTAMP_NTZ;scale=3", at.Truncate(time.Millisecond)},
+		{DbTypePostgresSQL, "CREATE TABLE t (at timestamp(0) NOT NULL)",
+			"name=at;type=time.Time;dbType=TIMESTAMP;scale=0", at.Truncate(time.Second)},
+		{DbTypeMsSQL, "CREATE TABLE t (At DATETIME2(3) NOT NULL)",
+			"name=At;type=time.Time;dbType=DATETIME2;scale=3", at.Truncate(time.Millisecond)},
+	}
+	for _, c := range cases {
+		specs, err := ParseCreateTable(c.ddl, c.dbType)
+		require.NoError(t, err, c.ddl)
+		assert.Equal(t, []string{c.spec}, specs, c.ddl)
+
+		// the emulated driver keeps only the declared fractional seconds
+		rs := NewMockRowSet(specs, c.dbType, EmulateDriver())
+		rs.AddRow([]any{at})
+		assert.Equal(t, []any{c.expected}, scanAny(t, rs), c.ddl)
+	}
+}
+
+func TestParseCreateTableWideIntegers(t *testing.T) {
+	specs, err := ParseCreateTable("CREATE TABLE t (big NUMERIC(20), small NUMERIC(18))", DbTypePostgresSQL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name=big;type=*float64;dbType=NUMERIC;precision=20;scale=0",
+		"name=small;type=*int64;dbType=NUMERIC;precision=18;scale=0",
+	}, specs)
+}
+
+func TestParseCreateTableProjection(t *testing.T) {
+	ddl := "CREATE TABLE people (id INT NOT NULL, name TEXT, age SMALLINT)"
+	specs, err := ParseCreateTable(ddl, DbTypePostgresSQL, "AGE", "id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name=age;type=*int16;dbType=SMALLINT",
+		"name=id;type=int32;dbType=INT",
+	}, specs)
+
+	_, err = ParseCreateTable(ddl, DbTypePostgresSQL, "email")
+	assert.EqualError(t, err, "column email is not in table people")
+}
+
+func TestParseCreateTableErrors(t *testing.T) {
+	cases := map[string]string{
+		"CREATE VIEW v AS SELECT 1":                "parsing CREATE TABLE: statement is not CREATE TABLE",
+		"DROP TABLE t":                             "parsing CREATE TABLE: statement does not start with CREATE",
+		"CREATE TABLE t (id INT":                   "parsing CREATE TABLE: column list is not closed",
+		"CREATE TABLE t (id INT, \"name TEXT)":     "parsing CREATE TABLE: \" is not closed",
+		"CREATE TABLE t (id)":                      "parsing CREATE TABLE: column id has no type",
+		"CREATE TABLE t (PRIMARY KEY (id))":        "parsing CREATE TABLE: table has no columns",
+		"CREATE TABLE t (doc TSVECTOR)":            "column doc: type TSVECTOR has no Go type",
+		"CREATE TABLE t (id INT) /* unterminated ": "parsing CREATE TABLE: comment is not closed",
+	}
+	for ddl, msg := range cases {
+		_, err := ParseCreateTable(ddl, DbTypePostgresSQL)
+		assert.EqualError(t, err, msg, ddl)
+	}
+
+	_, err := ParseCreateTable("CREATE TABLE t (id INT)", DatabaseType(99))
+	assert.EqualError(t, err, "invalid database type")
+}
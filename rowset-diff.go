@@ -0,0 +1,521 @@
+package sqlrows
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// TestingT is the part of *testing.T used by the assertions in this package
+	TestingT interface {
+		Helper()
+		Errorf(format string, args ...any)
+	}
+
+	DiffOption func(cfg *diffConfig)
+
+	diffConfig struct {
+		ignoreOrder    bool
+		ignoreMetadata bool
+		ignoreColumns  map[string]struct{}
+		caseless       bool
+		tolerance      float64
+		truncate       time.Duration
+	}
+
+	// RowSetDiff describes how an actual row set differs from the expected one
+	RowSetDiff struct {
+		Columns        []string  // the columns whose values were compared, named as expected
+		ColumnProblems []string  // missing, unexpected or mismatched columns
+		Rows           []RowDiff // rows added, removed or changed, in expected order
+	}
+
+	// RowDiff is one row of a RowSetDiff
+	RowDiff struct {
+		Kind          DiffKind
+		ExpectedIndex int    // zero-based row index in the expected set; -1 for added rows
+		ActualIndex   int    // zero-based row index in the actual set; -1 for removed rows
+		Expected      []any  // expected values of the compared columns; nil for added rows
+		Actual        []any  // actual values of the compared columns; nil for removed rows
+		Changed       []bool // for changed rows, which compared columns differ
+	}
+
+	DiffKind int
+
+	// alignStep pairs an item of one sequence with an item of another; the index
+	// is -1 on the side that has no counterpart
+	alignStep struct {
+		a, b int
+	}
+)
+
+const (
+	RowRemoved DiffKind = iota
+	RowAdded
+	RowChanged
+)
+
+// Compares rows as multisets, so the same rows in any order are equal.
+func IgnoreOrder() DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.ignoreOrder = true
+	}
+}
+
+// Leaves the named columns out of the comparison.
+func IgnoreColumns(names ...string) DiffOption {
+	return func(cfg *diffConfig) {
+		for _, name := range names {
+			cfg.ignoreColumns[strings.ToLower(name)] = struct{}{}
+		}
+	}
+}
+
+// Compares only column names and values, not the column types.
+func IgnoreMetadata() DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.ignoreMetadata = true
+	}
+}
+
+// Matches column names without regard to case.
+func CaseInsensitiveColumns() DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.caseless = true
+	}
+}
+
+// Treats numbers within [tolerance] of each other as equal.
+func FloatTolerance(tolerance float64) DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.tolerance = tolerance
+	}
+}
+
+// Truncates time values to a multiple of [d] before comparing them.
+func TruncateTimes(d time.Duration) DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.truncate = d
+	}
+}
+
+// Reads both row sets and reports how [actual] differs from [expected]. Both row
+// sets are closed once drained.
+func Diff(expected, actual RowSet, opts ...DiffOption) (*RowSetDiff, error) {
+	cfg := diffConfig{ignoreColumns: map[string]struct{}{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	expSrc, expRows, err := drain(expected)
+	if err != nil {
+		actual.Close()
+		return nil, fmt.Errorf("reading expected rows: %w", err)
+	}
+	actSrc, actRows, err := drain(actual)
+	if err != nil {
+		return nil, fmt.Errorf("reading actual rows: %w", err)
+	}
+
+	diff := &RowSetDiff{}
+
+	// pair up the columns by name
+	nameKey := func(name string) string {
+		if cfg.caseless {
+			return strings.ToLower(name)
+		}
+		return name
+	}
+	actIndex := map[string]int{}
+	for i, col := range actSrc.columns {
+		actIndex[nameKey(col)] = i
+	}
+	var expCols, actCols []int
+	matched := map[int]struct{}{}
+	for i, col := range expSrc.columns {
+		if _, ignored := cfg.ignoreColumns[strings.ToLower(col)]; ignored {
+			continue
+		}
+		j, found := actIndex[nameKey(col)]
+		if !found {
+			diff.ColumnProblems = append(diff.ColumnProblems, fmt.Sprintf("missing column %s", col))
+			continue
+		}
+		matched[j] = struct{}{}
+		expCols = append(expCols, i)
+		actCols = append(actCols, j)
+		diff.Columns = append(diff.Columns, col)
+		if !cfg.ignoreMetadata {
+			diff.ColumnProblems = append(diff.ColumnProblems, compareColumnTypes(expSrc.colTypes[i], actSrc.colTypes[j])...)
+		}
+	}
+	for j, col := range actSrc.columns {
+		if _, ignored := cfg.ignoreColumns[strings.ToLower(col)]; ignored {
+			continue
+		}
+		if _, found := matched[j]; !found {
+			diff.ColumnProblems = append(diff.ColumnProblems, fmt.Sprintf("unexpected column %s", col))
+		}
+	}
+
+	if !slices.IsSorted(actCols) {
+		order := make([]string, len(actCols))
+		sorted := slices.Sorted(slices.Values(actCols))
+		for i, j := range sorted {
+			order[i] = actSrc.columns[j]
+		}
+		diff.ColumnProblems = append(diff.ColumnProblems, fmt.Sprintf("columns are in order %s, expected %s", strings.Join(order, ", "), strings.Join(diff.Columns, ", ")))
+	}
+
+	pick := func(row []any, cols []int) []any {
+		vals := make([]any, len(cols))
+		for i, col := range cols {
+			vals[i] = row[col]
+		}
+		return vals
+	}
+	exp := make([][]any, len(expRows))
+	for i, row := range expRows {
+		exp[i] = pick(row, expCols)
+	}
+	act := make([][]any, len(actRows))
+	for i, row := range actRows {
+		act[i] = pick(row, actCols)
+	}
+
+	if cfg.ignoreOrder {
+		diff.Rows = cfg.diffUnordered(exp, act)
+	} else {
+		diff.Rows = cfg.diffOrdered(exp, act)
+	}
+	return diff, nil
+}
+
+// Fails the test with a readable diff when [actual] does not match [expected].
+// Both row sets are closed once drained.
+func AssertRowSetsEqual(t TestingT, expected, actual RowSet, opts ...DiffOption) bool {
+	t.Helper()
+	diff, err := Diff(expected, actual, opts...)
+	if err != nil {
+		t.Errorf("comparing row sets: %v", err)
+		return false
+	}
+	if !diff.Equal() {
+		t.Errorf("row sets differ:\n%s", diff)
+		return false
+	}
+	return true
+}
+
+// Reports whether no differences were found.
+func (d *RowSetDiff) Equal() bool {
+	return len(d.ColumnProblems) == 0 && len(d.Rows) == 0
+}
+
+// Renders the differences as a list of column problems followed by a table of
+// rows, marked - when removed, + when added and ~ when changed. Changed cells
+// show the expected and actual values as "expected → actual".
+func (d *RowSetDiff) String() string {
+	var sb strings.Builder
+	for _, problem := range d.ColumnProblems {
+		sb.WriteString(problem)
+		sb.WriteByte('\n')
+	}
+	if len(d.Rows) == 0 {
+		return sb.String()
+	}
+
+	table := textTable{
+		headers: append([]string{"", "row"}, d.Columns...),
+		numeric: make([]bool, len(d.Columns)+2),
+	}
+	for _, rd := range d.Rows {
+		cells := make([]string, 0, len(table.headers))
+		switch rd.Kind {
+		case RowRemoved:
+			cells = append(cells, "-", strconv.Itoa(rd.ExpectedIndex+1))
+			for _, v := range rd.Expected {
+				cells = append(cells, diffText(v))
+			}
+		case RowAdded:
+			cells = append(cells, "+", strconv.Itoa(rd.ActualIndex+1))
+			for _, v := range rd.Actual {
+				cells = append(cells, diffText(v))
+			}
+		case RowChanged:
+			cells = append(cells, "~", strconv.Itoa(rd.ExpectedIndex+1))
+			for i := range rd.Expected {
+				if rd.Changed[i] {
+					cells = append(cells, diffText(rd.Expected[i])+" → "+diffText(rd.Actual[i]))
+				} else {
+					cells = append(cells, diffText(rd.Expected[i]))
+				}
+			}
+		}
+		table.cells = append(table.cells, cells)
+	}
+	sb.WriteString(table.String())
+	return sb.String()
+}
+
+func diffText(v any) string {
+	v = normalizeValue(v)
+	if v == nil {
+		return "NULL"
+	}
+	if s, ok := v.(string); ok {
+		return escapeText(strconv.Quote(s), false)
+	}
+	return escapeText(valueString(v), false)
+}
+
+func drain(rs RowSet) (*sourceRows, [][]any, error) {
+	src, err := openSource(rs)
+	if err != nil {
+		rs.Close()
+		return nil, nil, err
+	}
+	rows, err := src.readAll()
+	if err != nil {
+		rs.Close()
+		return nil, nil, err
+	}
+	return src, rows, rs.Close()
+}
+
+// compareColumnTypes lists the metadata differences between two columns
+func compareColumnTypes(exp, act ColumnType) []string {
+	var problems []string
+	name := exp.Name()
+	if exp.DatabaseTypeName() != act.DatabaseTypeName() {
+		problems = append(problems, fmt.Sprintf("column %s database type is %s, expected %s", name, act.DatabaseTypeName(), exp.DatabaseTypeName()))
+	}
+	if exp.ScanType() != act.ScanType() {
+		problems = append(problems, fmt.Sprintf("column %s scan type is %v, expected %v", name, act.ScanType(), exp.ScanType()))
+	}
+	expNullable, expOk := exp.Nullable()
+	actNullable, actOk := act.Nullable()
+	if expNullable != actNullable || expOk != actOk {
+		problems = append(problems, fmt.Sprintf("column %s nullable is %v (ok=%v), expected %v (ok=%v)", name, actNullable, actOk, expNullable, expOk))
+	}
+	expLength, expOk := exp.Length()
+	actLength, actOk := act.Length()
+	if expLength != actLength || expOk != actOk {
+		problems = append(problems, fmt.Sprintf("column %s length is %d (ok=%v), expected %d (ok=%v)", name, actLength, actOk, expLength, expOk))
+	}
+	expPrecision, expScale, expOk := exp.DecimalSize()
+	actPrecision, actScale, actOk := act.DecimalSize()
+	if expPrecision != actPrecision || expScale != actScale || expOk != actOk {
+		problems = append(problems, fmt.Sprintf("column %s decimal size is (%d,%d) (ok=%v), expected (%d,%d) (ok=%v)", name, actPrecision, actScale, actOk, expPrecision, expScale, expOk))
+	}
+	return problems
+}
+
+// diffOrdered aligns the rows on their longest common subsequence, so an inserted
+// or deleted row is reported once rather than shifting every later row. Between
+// matched rows, removed and added rows are paired up in order as changed rows.
+// Very long, mostly different row sets are compared by position instead.
+func (cfg *diffConfig) diffOrdered(exp, act [][]any) []RowDiff {
+	var rows []RowDiff
+	var removed, added []int
+	flush := func() {
+		paired := min(len(removed), len(added))
+		for k := range paired {
+			i, j := removed[k], added[k]
+			changed := make([]bool, len(exp[i]))
+			for c := range exp[i] {
+				changed[c] = !cfg.valuesEqual(exp[i][c], act[j][c])
+			}
+			rows = append(rows, RowDiff{Kind: RowChanged, ExpectedIndex: i, ActualIndex: j, Expected: exp[i], Actual: act[j], Changed: changed})
+		}
+		for _, i := range removed[paired:] {
+			rows = append(rows, RowDiff{Kind: RowRemoved, ExpectedIndex: i, ActualIndex: -1, Expected: exp[i]})
+		}
+		for _, j := range added[paired:] {
+			rows = append(rows, RowDiff{Kind: RowAdded, ExpectedIndex: -1, ActualIndex: j, Actual: act[j]})
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	for _, step := range lcsAlign(len(exp), len(act), func(i, j int) bool { return cfg.rowsEqual(exp[i], act[j]) }) {
+		switch {
+		case step.b < 0:
+			removed = append(removed, step.a)
+		case step.a < 0:
+			added = append(added, step.b)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return rows
+}
+
+// maxAlignCells caps the LCS table lcsAlign builds, about 32MB of ints
+const maxAlignCells = 1 << 22
+
+// lcsAlign walks two sequences of [n] and [m] items along their longest common
+// subsequence, as decided by [equal]. Matching leading and trailing items are
+// paired up front so long, mostly equal sequences need only a small table.
+// When the rest would still need a table over maxAlignCells, items are
+// compared by position instead, in linear time and space.
+func lcsAlign(n, m int, equal func(i, j int) bool) []alignStep {
+	head := 0
+	for head < n && head < m && equal(head, head) {
+		head++
+	}
+	tail := 0
+	for tail < n-head && tail < m-head && equal(n-1-tail, m-1-tail) {
+		tail++
+	}
+
+	steps := make([]alignStep, 0, max(n, m))
+	for k := range head {
+		steps = append(steps, alignStep{a: k, b: k})
+	}
+
+	rows, cols := n-head-tail, m-head-tail
+	if (rows+1)*(cols+1) > maxAlignCells {
+		steps = positionalAlign(steps, head, rows, cols, equal)
+	} else {
+		steps = tableAlign(steps, head, rows, cols, equal)
+	}
+
+	for k := range tail {
+		steps = append(steps, alignStep{a: n - tail + k, b: m - tail + k})
+	}
+	return steps
+}
+
+// tableAlign appends the LCS alignment of the [rows] by [cols] items after [head]
+func tableAlign(steps []alignStep, head, rows, cols int, equal func(i, j int) bool) []alignStep {
+	lcs := make([][]int, rows+1)
+	for i := range lcs {
+		lcs[i] = make([]int, cols+1)
+	}
+	for i := rows - 1; i >= 0; i-- {
+		for j := cols - 1; j >= 0; j-- {
+			if equal(head+i, head+j) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < rows || j < cols {
+		switch {
+		case i < rows && j < cols && equal(head+i, head+j):
+			steps = append(steps, alignStep{a: head + i, b: head + j})
+			i++
+			j++
+		case i < rows && (j == cols || lcs[i+1][j] >= lcs[i][j+1]):
+			steps = append(steps, alignStep{a: head + i, b: -1})
+			i++
+		default:
+			steps = append(steps, alignStep{a: -1, b: head + j})
+			j++
+		}
+	}
+	return steps
+}
+
+// positionalAlign appends the [rows] and [cols] items after [head] paired by
+// position; an unequal pair is a removal and an addition, which diffOrdered
+// reports as a changed row
+func positionalAlign(steps []alignStep, head, rows, cols int, equal func(i, j int) bool) []alignStep {
+	for k := range min(rows, cols) {
+		if equal(head+k, head+k) {
+			steps = append(steps, alignStep{a: head + k, b: head + k})
+		} else {
+			steps = append(steps, alignStep{a: head + k, b: -1}, alignStep{a: -1, b: head + k})
+		}
+	}
+	for k := cols; k < rows; k++ {
+		steps = append(steps, alignStep{a: head + k, b: -1})
+	}
+	for k := rows; k < cols; k++ {
+		steps = append(steps, alignStep{a: -1, b: head + k})
+	}
+	return steps
+}
+
+func (cfg *diffConfig) diffUnordered(exp, act [][]any) []RowDiff {
+	used := make([]bool, len(act))
+	var rows []RowDiff
+	for i, erow := range exp {
+		found := false
+		for j, arow := range act {
+			if !used[j] && cfg.rowsEqual(erow, arow) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			rows = append(rows, RowDiff{Kind: RowRemoved, ExpectedIndex: i, ActualIndex: -1, Expected: erow})
+		}
+	}
+	for j, arow := range act {
+		if !used[j] {
+			rows = append(rows, RowDiff{Kind: RowAdded, ExpectedIndex: -1, ActualIndex: j, Actual: arow})
+		}
+	}
+	return rows
+}
+
+func (cfg *diffConfig) rowsEqual(a, b []any) bool {
+	for i := range a {
+		if !cfg.valuesEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cfg *diffConfig) valuesEqual(a, b any) bool {
+	a = normalizeValue(a)
+	b = normalizeValue(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if fa, ok := numericValue(a); ok {
+		fb, ok := numericValue(b)
+		if !ok {
+			return false
+		}
+		if isIntKind(reflect.ValueOf(a).Kind()) && isIntKind(reflect.ValueOf(b).Kind()) {
+			return compareValues(a, b) == 0
+		}
+		return fa == fb || math.Abs(fa-fb) <= cfg.tolerance
+	}
+
+	switch ta := a.(type) {
+	case time.Time:
+		tb, ok := b.(time.Time)
+		if !ok {
+			return false
+		}
+		if cfg.truncate > 0 {
+			ta = ta.Truncate(cfg.truncate)
+			tb = tb.Truncate(cfg.truncate)
+		}
+		return ta.Equal(tb)
+	case []byte:
+		tb, ok := b.([]byte)
+		return ok && bytes.Equal(ta, tb)
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	return compareValues(a, b) == 0
+}
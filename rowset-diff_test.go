@@ -0,0 +1,147 @@
+package sqlrows
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeT struct {
+	messages []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.messages = append(f.messages, fmt.Sprintf(format, args...))
+}
+
+func newPeopleFixture(t *testing.T, rows ...[]any) MockRowSet {
+	return newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string", "name=SCORE;type=float64"}, DbTypeSnowflake).
+		AddsRows(rows).rs
+}
+
+func TestAssertRowSetsEqual(t *testing.T) {
+	expected := newPeopleFixture(t, []any{int64(1), "Ann", 1.5}, []any{int64(2), "Bob", 2.5})
+	actual := newPeopleFixture(t, []any{int64(1), "Ann", 1.5}, []any{int64(2), "Bob", 2.5})
+	assert.True(t, AssertRowSetsEqual(t, expected, actual))
+}
+
+func TestDiffChangedAddedRemoved(t *testing.T) {
+	expected := newPeopleFixture(t, []any{int64(1), "Ann", 1.5}, []any{int64(2), "Bob", 2.5}, []any{int64(3), "Cy", 3.5})
+	actual := newPeopleFixture(t, []any{int64(1), "Ann", 1.5}, []any{int64(2), "Rob", 2.5})
+
+	ft := &fakeT{}
+	assert.False(t, AssertRowSetsEqual(ft, expected, actual))
+	require.Len(t, ft.messages, 1)
+	assert.Equal(t, ""+
+		"row sets differ:\n"+
+		"+---+-----+----+---------------+-------+\n"+
+		"|   | row | ID | NAME          | SCORE |\n"+
+		"+---+-----+----+---------------+-------+\n"+
+		"| ~ | 2   | 2  | \"Bob\" → \"Rob\" | 2.5   |\n"+
+		"| - | 3   | 3  | \"Cy\"          | 3.5   |\n"+
+		"+---+-----+----+---------------+-------+\n",
+		ft.messages[0])
+}
+
+func TestDiffInsertedRow(t *testing.T) {
+	expected := newPeopleFixture(t, []any{int64(1), "Ann", 1.5}, []any{int64(2), "Bob", 2.5}, []any{int64(3), "Cy", 3.5}, []any{int64(4), "Di", 4.5})
+	actual := newPeopleFixture(t, []any{int64(1), "Ann", 1.5}, []any{int64(9), "New", 0.5}, []any{int64(2), "Bob", 2.5}, []any{int64(3), "Cy", 3.0})
+
+	diff, err := Diff(expected, actual)
+	require.NoError(t, err)
+	require.Len(t, diff.Rows, 3)
+	assert.Equal(t, RowDiff{Kind: RowAdded, ExpectedIndex: -1, ActualIndex: 1, Actual: []any{int64(9), "New", 0.5}}, diff.Rows[0])
+	assert.Equal(t, RowChanged, diff.Rows[1].Kind)
+	assert.Equal(t, []int{2, 3}, []int{diff.Rows[1].ExpectedIndex, diff.Rows[1].ActualIndex})
+	assert.Equal(t, []bool{false, false, true}, diff.Rows[1].Changed)
+	assert.Equal(t, RowDiff{Kind: RowRemoved, ExpectedIndex: 3, ActualIndex: -1, Expected: []any{int64(4), "Di", 4.5}}, diff.Rows[2])
+}
+
+func TestDiffLargeRowSets(t *testing.T) {
+	// a row inserted at the front of a long row set would need an LCS table over
+	// the cap, so the rows are compared by position instead
+	const count = 3000
+	var expRows, actRows [][]any
+	actRows = append(actRows, []any{int64(-1), "new", 0.0})
+	for i := range count {
+		row := []any{int64(i), fmt.Sprintf("person %d", i), float64(i)}
+		expRows = append(expRows, row)
+		if i < count-1 {
+			actRows = append(actRows, row)
+		}
+	}
+
+	diff, err := Diff(newPeopleFixture(t, expRows...), newPeopleFixture(t, actRows...))
+	require.NoError(t, err)
+	require.Len(t, diff.Rows, count)
+	for k, row := range diff.Rows {
+		assert.Equal(t, RowChanged, row.Kind)
+		assert.Equal(t, []int{k, k}, []int{row.ExpectedIndex, row.ActualIndex})
+	}
+}
+
+func TestDiffColumns(t *testing.T) {
+	expected := newPeopleFixture(t)
+	actual := NewMockRowSet([]string{"name=id;type=int64", "name=SCORE;type=float32", "name=EXTRA;type=string"}, DbTypeSnowflake)
+
+	diff, err := Diff(expected, actual, CaseInsensitiveColumns())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"missing column NAME",
+		"column SCORE database type is FLOAT, expected DOUBLE",
+		"column SCORE scan type is float32, expected float64",
+		"unexpected column EXTRA",
+	}, diff.ColumnProblems)
+
+	expected = newPeopleFixture(t)
+	actual = NewMockRowSet([]string{"name=id;type=int64", "name=SCORE;type=float32", "name=EXTRA;type=string"}, DbTypeSnowflake)
+	diff, err = Diff(expected, actual, CaseInsensitiveColumns(), IgnoreColumns("name", "extra"), IgnoreMetadata())
+	require.NoError(t, err)
+	assert.Empty(t, diff.ColumnProblems)
+
+	expected = newPeopleFixture(t)
+	actual = NewMockRowSet([]string{"name=NAME;type=string", "name=ID;type=int64", "name=SCORE;type=float64"}, DbTypeSnowflake)
+	diff, err = Diff(expected, actual)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"columns are in order NAME, ID, SCORE, expected ID, NAME, SCORE"}, diff.ColumnProblems)
+}
+
+func TestDiffOptions(t *testing.T) {
+	expected := newPeopleFixture(t, []any{int64(1), "Ann", 1.5}, []any{int64(2), "Bob", 2.5})
+	actual := newPeopleFixture(t, []any{int64(2), "Bob", 2.5000001}, []any{int64(1), "Ann", 1.5})
+	assert.True(t, AssertRowSetsEqual(t, expected, actual, IgnoreOrder(), FloatTolerance(1e-6)))
+
+	expected = newPeopleFixture(t, []any{int64(1), "Ann", 1.5})
+	actual = newPeopleFixture(t, []any{int64(2), "Bob", 2.5}, []any{int64(1), "Ann", 1.5})
+	diff, err := Diff(expected, actual, IgnoreOrder())
+	require.NoError(t, err)
+	require.Len(t, diff.Rows, 1)
+	assert.Equal(t, RowAdded, diff.Rows[0].Kind)
+	assert.Equal(t, 0, diff.Rows[0].ActualIndex)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	mk := func(v time.Time) MockRowSet {
+		rs := NewMockRowSet([]string{"name=TS;type=time.Time"}, DbTypePostgresSQL)
+		rs.AddRow([]any{v})
+		return rs
+	}
+	assert.True(t, AssertRowSetsEqual(t, mk(ts), mk(ts.Add(300).In(time.FixedZone("X", 3600))), TruncateTimes(time.Microsecond)))
+	diff, err = Diff(mk(ts), mk(ts.Add(time.Millisecond)), TruncateTimes(time.Microsecond))
+	require.NoError(t, err)
+	assert.False(t, diff.Equal())
+}
+
+func TestDiffNullsAndTypes(t *testing.T) {
+	cfg := diffConfig{}
+	assert.True(t, cfg.valuesEqual(nil, (*string)(nil)))
+	assert.False(t, cfg.valuesEqual(nil, ""))
+	assert.True(t, cfg.valuesEqual(int32(5), int64(5)))
+	assert.False(t, cfg.valuesEqual("5", int64(5)))
+	assert.True(t, cfg.valuesEqual([]byte("ab"), []byte("ab")))
+}
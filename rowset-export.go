@@ -0,0 +1,267 @@
+package sqlrows
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ExportOption func(cfg *exportConfig)
+
+	exportConfig struct {
+		nullText   string
+		timeFormat string
+		header     bool
+	}
+)
+
+// Sets the text written for NULL in CSV output. The default is an empty field.
+func NullAs(text string) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.nullText = text
+	}
+}
+
+// Sets the layout used for time values in CSV and JSON Lines output. The default
+// is time.RFC3339Nano.
+func TimeFormat(layout string) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.timeFormat = layout
+	}
+}
+
+// Controls whether CSV output starts with a row of column names. The default is true.
+func Header(include bool) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.header = include
+	}
+}
+
+func newExportConfig(opts []ExportOption) exportConfig {
+	cfg := exportConfig{
+		timeFormat: time.RFC3339Nano,
+		header:     true,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Writes the rows of [rs] to [w] as CSV. Decimal, UUID and JSON columns are
+// written as their text, whether the driver delivers it as a string or as
+// bytes, and Go numbers in decimal columns with the scale from their column
+// type. Only binary columns are base64 encoded. [rs] is closed once drained.
+func WriteCSV(w io.Writer, rs RowSet, opts ...ExportOption) error {
+	cfg := newExportConfig(opts)
+	src, err := openSource(rs)
+	if err != nil {
+		return errors.Join(err, rs.Close())
+	}
+
+	cw := csv.NewWriter(w)
+	if cfg.header {
+		if err = cw.Write(src.columns); err != nil {
+			return errors.Join(err, rs.Close())
+		}
+	}
+
+	record := make([]string, len(src.columns))
+	for {
+		row, err := src.next()
+		if err != nil {
+			return errors.Join(err, rs.Close())
+		}
+		if row == nil {
+			break
+		}
+		for i, v := range row {
+			if v == nil {
+				record[i] = cfg.nullText
+			} else {
+				record[i] = cfg.formatText(v, src.colTypes[i])
+			}
+		}
+		if err = cw.Write(record); err != nil {
+			return errors.Join(err, rs.Close())
+		}
+	}
+
+	cw.Flush()
+	return errors.Join(cw.Error(), rs.Close())
+}
+
+// Writes the rows of [rs] to [w] as JSON Lines, one object per row with keys in
+// column order. NULL is written as null, decimal columns as JSON numbers holding
+// the driver's digits, binary columns as base64 strings, and times, UUIDs, JSON
+// and other text as strings. [rs] is closed once drained.
+func WriteJSONLines(w io.Writer, rs RowSet, opts ...ExportOption) error {
+	cfg := newExportConfig(opts)
+	src, err := openSource(rs)
+	if err != nil {
+		return errors.Join(err, rs.Close())
+	}
+
+	keys := make([][]byte, len(src.columns))
+	for i, col := range src.columns {
+		if keys[i], err = json.Marshal(col); err != nil {
+			return errors.Join(err, rs.Close())
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for {
+		row, err := src.next()
+		if err != nil {
+			return errors.Join(err, rs.Close())
+		}
+		if row == nil {
+			break
+		}
+
+		bw.WriteByte('{')
+		for i, v := range row {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			bw.Write(keys[i])
+			bw.WriteByte(':')
+			encoded, err := cfg.formatJSON(v, src.colTypes[i])
+			if err != nil {
+				return errors.Join(err, rs.Close())
+			}
+			bw.Write(encoded)
+		}
+		bw.WriteString("}\n")
+	}
+
+	return errors.Join(bw.Flush(), rs.Close())
+}
+
+// formatText renders a non-NULL value as CSV text
+func (cfg *exportConfig) formatText(v any, ct ColumnType) string {
+	switch tv := v.(type) {
+	case time.Time:
+		return tv.Format(cfg.timeFormat)
+	case uuid.UUID:
+		return tv.String()
+	}
+
+	if rv := reflect.ValueOf(v); isBytesKind(rv.Type()) {
+		if isBinaryColumn(ct) {
+			return base64.StdEncoding.EncodeToString(rv.Bytes())
+		}
+		return bytesText(rv.Bytes(), ct)
+	}
+	if isDecimalColumn(ct) {
+		return exportDecimal(v, ct)
+	}
+	return valueString(v)
+}
+
+// formatJSON renders a value as JSON
+func (cfg *exportConfig) formatJSON(v any, ct ColumnType) ([]byte, error) {
+	switch tv := v.(type) {
+	case nil:
+		return []byte("null"), nil
+	case time.Time:
+		return json.Marshal(tv.Format(cfg.timeFormat))
+	case complex64, complex128:
+		return json.Marshal(valueString(tv))
+	case uuid.UUID:
+		return json.Marshal(tv.String())
+	}
+
+	rv := reflect.ValueOf(v)
+	if isBytesKind(rv.Type()) && isBinaryColumn(ct) {
+		// plain []byte, so that a json.RawMessage is encoded too
+		return json.Marshal(rv.Bytes())
+	}
+	if isDecimalColumn(ct) {
+		text := exportDecimal(v, ct)
+		if encoded, err := json.Marshal(json.Number(text)); err == nil {
+			return encoded, nil
+		}
+		// NaN and Infinity have no JSON number
+		return json.Marshal(text)
+	}
+	if isBytesKind(rv.Type()) {
+		return json.Marshal(bytesText(rv.Bytes(), ct))
+	}
+	return json.Marshal(v)
+}
+
+// isBinaryColumn tells whether a column holds binary data, which is exported
+// base64 encoded. Drivers deliver decimals, UUIDs, JSON and other text as
+// bytes too, so the column's database type decides.
+func isBinaryColumn(ct ColumnType) bool {
+	decl := driverTypeDecl(ct.DatabaseTypeName())
+	switch decl.name {
+	case "BINARY", "VARBINARY", "BYTEA", "IMAGE", "BLOB", "ROWVERSION":
+		return true
+	case "":
+		scanType := baseScanType(ct)
+		return scanType != nil && isBytesKind(scanType)
+	}
+	return false
+}
+
+// isDecimalColumn tells whether a column holds exact decimals
+func isDecimalColumn(ct ColumnType) bool {
+	decl := driverTypeDecl(ct.DatabaseTypeName())
+	if decl.class() == "decimal" {
+		return true
+	}
+	_, scale, ok := ct.DecimalSize()
+	return ok && scale > 0
+}
+
+// bytesText renders the bytes a driver delivered for a non-binary column as
+// the text they stand for
+func bytesText(b []byte, ct ColumnType) string {
+	if len(b) == 16 {
+		switch driverTypeDecl(ct.DatabaseTypeName()).name {
+		case "UNIQUEIDENTIFIER":
+			// go-mssqldb delivers a GUID in SQL Server's mixed byte order
+			return uuid.UUID(mssqlGUIDBytes(uuid.UUID(b))).String()
+		case "UUID":
+			return uuid.UUID(b).String()
+		}
+	}
+	return string(b)
+}
+
+// exportDecimal renders a value of a decimal column. Text from the driver is
+// kept as is, so no digits are lost; Go numbers are written with the column's
+// scale.
+func exportDecimal(v any, ct ColumnType) string {
+	_, scale, ok := ct.DecimalSize()
+	if !ok {
+		scale = 0
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case isBytesKind(rv.Type()):
+		return string(rv.Bytes())
+	case rv.CanFloat():
+		digits := -1
+		if scale > 0 {
+			digits = int(scale)
+		}
+		return strconv.FormatFloat(rv.Float(), 'f', digits, 64)
+	case isIntKind(rv.Kind()) && scale > 0:
+		return valueString(v) + "." + strings.Repeat("0", int(scale))
+	}
+	return valueString(v)
+}
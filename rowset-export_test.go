@@ -0,0 +1,122 @@
+package sqlrows
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExportFixture(t *testing.T) MockRowSet {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	name := "Widget, large"
+	data := `{"a":1}`
+	return newTestCommon(t).
+		HasMockRowSet([]string{
+			"name=ID;type=int64",
+			"name=NAME;type=*string",
+			"name=PRICE;type=float64;precision=10;scale=2;dbType=NUMBER",
+			"name=CREATED;type=*time.Time;dbType=TIMESTAMP_NTZ",
+			"name=DATA;type=*string",
+		}, DbTypeSnowflake).
+		AddsRows([][]any{
+			{int64(1), &name, 10.5, &created, &data},
+			{int64(2), nil, 3.0, nil, nil},
+		}).rs
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, newExportFixture(t)))
+	assert.Equal(t,
+		"ID,NAME,PRICE,CREATED,DATA\n"+
+			"1,\"Widget, large\",10.50,2024-03-01T12:30:00.123456789Z,\"{\"\"a\"\":1}\"\n"+
+			"2,,3.00,,\n",
+		buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteCSV(&buf, newExportFixture(t), Header(false), NullAs(`\N`), TimeFormat(time.DateOnly)))
+	assert.Equal(t,
+		"1,\"Widget, large\",10.50,2024-03-01,\"{\"\"a\"\":1}\"\n"+
+			"2,\\N,3.00,\\N,\\N\n",
+		buf.String())
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONLines(&buf, newExportFixture(t)))
+	assert.Equal(t,
+		`{"ID":1,"NAME":"Widget, large","PRICE":10.50,"CREATED":"2024-03-01T12:30:00.123456789Z","DATA":"{\"a\":1}"}`+"\n"+
+			`{"ID":2,"NAME":null,"PRICE":3.00,"CREATED":null,"DATA":null}`+"\n",
+		buf.String())
+}
+
+func TestWriteDriverText(t *testing.T) {
+	id := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	cases := []struct {
+		dbType DatabaseType
+		cols   []string
+	}{
+		{DbTypePostgresSQL, []string{
+			"name=PRICE;type=float64;dbType=NUMERIC(10,2)",
+			"name=BIG;type=string;dbType=NUMERIC(38,10)",
+			"name=REF;type=uuid.UUID",
+			"name=DOC;type=string;dbType=JSONB",
+			"name=BODY;type=[]byte",
+		}},
+		{DbTypeMsSQL, []string{
+			"name=PRICE;type=float64;dbType=DECIMAL(10,2)",
+			"name=BIG;type=string;dbType=DECIMAL(38,10)",
+			"name=REF;type=uuid.UUID",
+			"name=DOC;type=string;dbType=NVARCHAR(MAX)",
+			"name=BODY;type=[]byte",
+		}},
+		{DbTypeSnowflake, []string{
+			"name=PRICE;type=string;dbType=NUMBER(10,2)",
+			"name=BIG;type=string;dbType=NUMBER(38,10)",
+			"name=REF;type=string",
+			"name=DOC;type=string;dbType=VARIANT",
+			"name=BODY;type=[]byte",
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(dbTypeConstants[c.dbType], func(t *testing.T) {
+			newRows := func() RowSet {
+				it := newTestCommon(t).HasMockRowSet(c.cols, c.dbType, EmulateDriver())
+				price := any(12.5)
+				if c.dbType == DbTypeSnowflake {
+					price = "12.50"
+				}
+				ref := any(id)
+				if c.dbType == DbTypeSnowflake {
+					ref = id.String()
+				}
+				it.AddsRows([][]any{{price, "1234567890123456789012345678.0123456789", ref, `{"a":1}`, []byte{0xde, 0xad}}})
+				return it.rs
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, WriteCSV(&buf, newRows(), Header(false)))
+			assert.Equal(t, "12.50,1234567890123456789012345678.0123456789,"+id.String()+",\"{\"\"a\"\":1}\",3q0=\n", buf.String())
+
+			buf.Reset()
+			require.NoError(t, WriteJSONLines(&buf, newRows()))
+			assert.Equal(t, `{"PRICE":12.50,"BIG":1234567890123456789012345678.0123456789,"REF":"`+id.String()+
+				`","DOC":"{\"a\":1}","BODY":"3q0="}`+"\n", buf.String())
+		})
+	}
+}
+
+func TestWriteExportSourceError(t *testing.T) {
+	src := &errRowSet{RowSet: newExportFixture(t)}
+	assert.EqualError(t, WriteCSV(&bytes.Buffer{}, src), "connection reset")
+	assert.True(t, src.closed)
+
+	src = &errRowSet{RowSet: newExportFixture(t)}
+	assert.EqualError(t, WriteJSONLines(&bytes.Buffer{}, src), "connection reset")
+	assert.True(t, src.closed)
+}
@@ -0,0 +1,250 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	// FormatOptions controls how Format renders a row set as a text table
+	FormatOptions struct {
+		Markdown   bool   // render a Markdown table instead of an ASCII box
+		ShowTypes  bool   // include each column's DatabaseTypeName in the header
+		NullText   string // text shown for NULL; "NULL" when empty
+		MaxWidth   int    // truncate values longer than this many characters; 0 for no limit
+		MaxRows    int    // render at most this many rows, noting that more follow; 0 for no limit
+		TimeFormat string // layout for time values; time.RFC3339Nano when empty
+	}
+
+	textTable struct {
+		headers  []string
+		meta     [][]string // header rows describing each column, below the names
+		cells    [][]string
+		numeric  []bool
+		markdown bool
+	}
+)
+
+// generatedStringRows is how many rows String produces for a generated mock
+const generatedStringRows = 20
+
+// Renders the rows of [rs] as an aligned text table, for test failure messages and
+// debugging. [rs] is closed once drained, or once MaxRows rows have been read.
+func Format(rs RowSet, opts FormatOptions) (string, error) {
+	src, err := openSource(rs)
+	if err != nil {
+		return "", errors.Join(err, rs.Close())
+	}
+
+	var rows [][]any
+	more := false
+	for {
+		if opts.MaxRows > 0 && len(rows) == opts.MaxRows {
+			if more = rs.Next(); !more {
+				err = rs.Err()
+			}
+			break
+		}
+		var row []any
+		if row, err = src.next(); err != nil || row == nil {
+			break
+		}
+		rows = append(rows, row)
+	}
+	if err != nil {
+		return "", errors.Join(err, rs.Close())
+	}
+	if err = rs.Close(); err != nil {
+		return "", err
+	}
+
+	text := formatTable(src.columns, src.colTypes, rows, opts)
+	if more {
+		text += "… more rows not shown\n"
+	}
+	return text, nil
+}
+
+// Renders the mock table as text, so %v prints the fixture. The cursor is not moved.
+// A generated mock prints its first rows, produced again by its generator, and
+// notes how many more it has.
+func (m *mockRowSet) String() string {
+	colTypes := make([]ColumnType, 0, len(m.columnTypes))
+	for _, ct := range m.columnTypes {
+		colTypes = append(colTypes, ct)
+	}
+
+	rows := m.values
+	omitted := 0
+	if m.generate != nil {
+		n := min(m.count, generatedStringRows)
+		rows = make([][]any, 0, n)
+		for i := range n {
+			rows = append(rows, m.generate(i))
+		}
+		omitted = m.count - n
+	}
+
+	text := formatTable(m.columns, colTypes, rows, FormatOptions{ShowTypes: true, MaxWidth: 40})
+	if omitted > 0 {
+		text += fmt.Sprintf("… %d more generated rows\n", omitted)
+	}
+	return text
+}
+
+func formatTable(columns []string, colTypes []ColumnType, rows [][]any, opts FormatOptions) string {
+	if opts.NullText == "" {
+		opts.NullText = "NULL"
+	}
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = time.RFC3339Nano
+	}
+
+	table := textTable{
+		headers:  columns,
+		numeric:  make([]bool, len(columns)),
+		markdown: opts.Markdown,
+	}
+	if opts.ShowTypes {
+		types := make([]string, len(columns))
+		for i, ct := range colTypes {
+			types[i] = ct.DatabaseTypeName()
+		}
+		table.meta = append(table.meta, types)
+	}
+	for i, ct := range colTypes {
+		table.numeric[i] = classifyNumeric(ct) != classOther
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i := range columns {
+			var v any
+			if i < len(row) {
+				v = normalizeValue(row[i])
+			}
+			switch tv := v.(type) {
+			case nil:
+				cells[i] = opts.NullText
+			case time.Time:
+				cells[i] = tv.Format(opts.TimeFormat)
+			default:
+				cells[i] = valueString(tv)
+			}
+			cells[i] = truncateText(escapeText(cells[i], opts.Markdown), opts.MaxWidth)
+		}
+		table.cells = append(table.cells, cells)
+	}
+
+	return table.String()
+}
+
+// escapeText keeps a value on one line, and out of the Markdown column syntax
+func escapeText(text string, markdown bool) string {
+	text = strings.NewReplacer("\r", `\r`, "\n", `\n`, "\t", `\t`).Replace(text)
+	if markdown {
+		text = strings.ReplaceAll(text, "|", `\|`)
+	}
+	return text
+}
+
+func truncateText(text string, maxWidth int) string {
+	if maxWidth <= 0 || utf8.RuneCountInString(text) <= maxWidth {
+		return text
+	}
+	if maxWidth == 1 {
+		return "…"
+	}
+	return string([]rune(text)[:maxWidth-1]) + "…"
+}
+
+func (tt *textTable) String() string {
+	headers := tt.headers
+	if tt.meta != nil && tt.markdown {
+		headers = make([]string, len(tt.headers))
+		for i, name := range tt.headers {
+			details := make([]string, 0, len(tt.meta))
+			for _, meta := range tt.meta {
+				details = append(details, meta[i])
+			}
+			headers[i] = name + " (" + strings.Join(details, ", ") + ")"
+		}
+	}
+
+	widths := make([]int, len(headers))
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	measure(headers)
+	if !tt.markdown {
+		for _, meta := range tt.meta {
+			measure(meta)
+		}
+	}
+	for _, cells := range tt.cells {
+		measure(cells)
+	}
+
+	var sb strings.Builder
+	if tt.markdown {
+		tt.writeLine(&sb, headers, widths, false)
+		sb.WriteByte('|')
+		for i, width := range widths {
+			sb.WriteString(" ")
+			if tt.numeric[i] {
+				sb.WriteString(strings.Repeat("-", max(width-1, 2)) + ":")
+			} else {
+				sb.WriteString(strings.Repeat("-", max(width, 3)))
+			}
+			sb.WriteString(" |")
+		}
+		sb.WriteByte('\n')
+		for _, cells := range tt.cells {
+			tt.writeLine(&sb, cells, widths, true)
+		}
+		return sb.String()
+	}
+
+	tt.writeBorder(&sb, widths)
+	tt.writeLine(&sb, headers, widths, false)
+	for _, meta := range tt.meta {
+		tt.writeLine(&sb, meta, widths, false)
+	}
+	tt.writeBorder(&sb, widths)
+	for _, cells := range tt.cells {
+		tt.writeLine(&sb, cells, widths, true)
+	}
+	if len(tt.cells) > 0 {
+		tt.writeBorder(&sb, widths)
+	}
+	return sb.String()
+}
+
+func (tt *textTable) writeBorder(sb *strings.Builder, widths []int) {
+	sb.WriteByte('+')
+	for _, width := range widths {
+		sb.WriteString(strings.Repeat("-", width+2))
+		sb.WriteByte('+')
+	}
+	sb.WriteByte('\n')
+}
+
+func (tt *textTable) writeLine(sb *strings.Builder, cells []string, widths []int, align bool) {
+	sb.WriteByte('|')
+	for i, cell := range cells {
+		padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		sb.WriteByte(' ')
+		if align && tt.numeric[i] {
+			sb.WriteString(padding + cell)
+		} else {
+			sb.WriteString(cell + padding)
+		}
+		sb.WriteString(" |")
+	}
+	sb.WriteByte('\n')
+}
@@ -0,0 +1,113 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAscii(t *testing.T) {
+	text, err := Format(newExportFixture(t), FormatOptions{ShowTypes: true, MaxWidth: 10, TimeFormat: "2006-01-02"})
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"+--------+------------+--------+---------------+---------+\n"+
+		"| ID     | NAME       | PRICE  | CREATED       | DATA    |\n"+
+		"| BIGINT | VARCHAR    | NUMBER | TIMESTAMP_NTZ | VARCHAR |\n"+
+		"+--------+------------+--------+---------------+---------+\n"+
+		"|      1 | Widget, l… |   10.5 | 2024-03-01    | {\"a\":1} |\n"+
+		"|      2 | NULL       |      3 | NULL          | NULL    |\n"+
+		"+--------+------------+--------+---------------+---------+\n",
+		text)
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	rs := NewMockRowSet([]string{"name=ID;type=int", "name=NOTE;type=*string"}, DbTypePostgresSQL)
+	note := "a|b\nc"
+	rs.AddRow([]any{1, &note})
+	rs.AddRow([]any{22, nil})
+
+	text, err := Format(rs, FormatOptions{Markdown: true, ShowTypes: true, NullText: "∅"})
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"| ID (INTEGER) | NOTE (TEXT) |\n"+
+		"| -----------: | ----------- |\n"+
+		"|            1 | a\\|b\\nc     |\n"+
+		"|           22 | ∅           |\n",
+		text)
+}
+
+func TestFormatEmpty(t *testing.T) {
+	rs := NewMockRowSet([]string{"name=ID;type=int"}, DbTypeMsSQL)
+	text, err := Format(rs, FormatOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "+----+\n| ID |\n+----+\n", text)
+
+	rs.Rewind()
+	_, err = Format(&errRowSet{RowSet: rs}, FormatOptions{})
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestFormatMaxRows(t *testing.T) {
+	rs := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypePostgresSQL)
+	for i := range 3 {
+		rs.AddRow([]any{int64(i)})
+	}
+
+	text, err := Format(rs, FormatOptions{MaxRows: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "+----+\n| ID |\n+----+\n|  0 |\n|  1 |\n+----+\n… more rows not shown\n", text)
+	assert.False(t, rs.Next(), "Format should close the rows at the limit")
+
+	rs.Rewind()
+	text, err = Format(rs, FormatOptions{MaxRows: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "+----+\n| ID |\n+----+\n|  0 |\n|  1 |\n|  2 |\n+----+\n", text)
+
+	// an error in place of the row past the limit is still reported
+	failing := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypePostgresSQL, FailAfter(1, errors.New("connection reset")))
+	failing.AddRow([]any{int64(0)})
+	failing.AddRow([]any{int64(1)})
+	_, err = Format(failing, FormatOptions{MaxRows: 1})
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestGeneratedRowSetString(t *testing.T) {
+	rs := NewGeneratedRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, 1_000_000,
+		func(i int) []any { return []any{int64(i)} })
+
+	text := fmt.Sprintf("%v", rs)
+	assert.True(t, strings.HasPrefix(text, "+--------+\n| ID     |\n| BIGINT |\n+--------+\n|      0 |\n"), text)
+	assert.Contains(t, text, "|     19 |\n+--------+\n… 999980 more generated rows\n")
+	assert.NotContains(t, text, "|     20 |")
+
+	small := NewGeneratedRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake, 2,
+		func(i int) []any { return []any{int64(i)} })
+	assert.Equal(t, "+--------+\n| ID     |\n| BIGINT |\n+--------+\n|      0 |\n|      1 |\n+--------+\n", fmt.Sprintf("%v", small))
+}
+
+func TestMockRowSetString(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string"}, DbTypeSnowflake).
+		AddsRows([][]any{{int64(1), "one"}, {int64(2), "two"}})
+
+	require.True(t, it.rs.Next())
+	expected := "" +
+		"+--------+---------+\n" +
+		"| ID     | NAME    |\n" +
+		"| BIGINT | VARCHAR |\n" +
+		"+--------+---------+\n" +
+		"|      1 | one     |\n" +
+		"|      2 | two     |\n" +
+		"+--------+---------+\n"
+	assert.Equal(t, expected, fmt.Sprintf("%v", it.rs))
+
+	// printing must not move the cursor
+	var id int64
+	var name string
+	require.NoError(t, it.rs.Scan(&id, &name))
+	assert.Equal(t, int64(1), id)
+}
@@ -0,0 +1,167 @@
+package sqlrows
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	GoldenOption func(cfg *goldenConfig)
+
+	goldenConfig struct {
+		update bool
+	}
+)
+
+// environment variable that, when set to a true value, makes AssertGolden
+// rewrite golden files instead of comparing against them
+const goldenUpdateEnv = "SQLROWS_UPDATE_GOLDEN"
+
+// Writes the golden file instead of comparing against it when [update] is true.
+// This overrides the -update flag and the SQLROWS_UPDATE_GOLDEN environment
+// variable.
+func UpdateGolden(update bool) GoldenOption {
+	return func(cfg *goldenConfig) {
+		cfg.update = update
+	}
+}
+
+// Compares the rows and column metadata of [rs] against the golden file at
+// [path], failing the test with a line diff on mismatch. To write the golden
+// file instead, run the tests with -update, if the test binary defines that
+// flag, or with the environment variable SQLROWS_UPDATE_GOLDEN=1, or pass
+// UpdateGolden(true). [rs] is closed once drained.
+func AssertGolden(t TestingT, rs RowSet, path string, opts ...GoldenOption) bool {
+	t.Helper()
+
+	cfg := goldenConfig{update: goldenUpdateDefault()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	actual, err := goldenText(rs)
+	if err != nil {
+		t.Errorf("reading row set for golden file %s: %v", path, err)
+		return false
+	}
+
+	if cfg.update {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, []byte(actual), 0o644)
+		}
+		if err != nil {
+			t.Errorf("updating golden file: %v", err)
+			return false
+		}
+		return true
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Errorf("golden file %s does not exist; run with -update or set %s=1 to create it", path, goldenUpdateEnv)
+		return false
+	}
+	if err != nil {
+		t.Errorf("reading golden file: %v", err)
+		return false
+	}
+
+	if string(expected) != actual {
+		t.Errorf("row set does not match golden file %s (- golden, + actual):\n%s", path, lineDiff(string(expected), actual))
+		return false
+	}
+	return true
+}
+
+// goldenUpdateDefault reports whether golden files are being updated: the
+// package does not register a global flag of its own, but honors an -update
+// flag that the test binary defines, as well as SQLROWS_UPDATE_GOLDEN
+func goldenUpdateDefault() bool {
+	if f := flag.Lookup("update"); f != nil {
+		if update, err := strconv.ParseBool(f.Value.String()); err == nil && update {
+			return true
+		}
+	}
+	update, _ := strconv.ParseBool(os.Getenv(goldenUpdateEnv))
+	return update
+}
+
+// goldenText serializes a row set as a table whose header rows hold the column
+// metadata. Times are written in UTC and strings are quoted, so the text depends
+// only on the values.
+func goldenText(rs RowSet) (string, error) {
+	src, rows, err := drain(rs)
+	if err != nil {
+		return "", err
+	}
+
+	table := textTable{
+		headers: src.columns,
+		numeric: make([]bool, len(src.columns)),
+		meta:    make([][]string, 5),
+	}
+	for i := range table.meta {
+		table.meta[i] = make([]string, len(src.columns))
+	}
+	for i, ct := range src.colTypes {
+		table.meta[0][i] = ct.DatabaseTypeName()
+		table.meta[1][i] = fmt.Sprint(ct.ScanType())
+
+		nullable, ok := ct.Nullable()
+		switch {
+		case !ok:
+			table.meta[2][i] = "nullable=?"
+		default:
+			table.meta[2][i] = "nullable=" + strconv.FormatBool(nullable)
+		}
+
+		table.meta[3][i] = "length=-"
+		if length, ok := ct.Length(); ok {
+			table.meta[3][i] = "length=" + strconv.FormatInt(length, 10)
+		}
+
+		table.meta[4][i] = "decimal=-"
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			table.meta[4][i] = fmt.Sprintf("decimal=%d,%d", precision, scale)
+		}
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			if tv, ok := v.(time.Time); ok {
+				v = tv.UTC()
+			}
+			cells[i] = diffText(v)
+		}
+		table.cells = append(table.cells, cells)
+	}
+
+	return table.String(), nil
+}
+
+// lineDiff renders the differences between two texts line by line, using the
+// longest common subsequence of lines
+func lineDiff(expected, actual string) string {
+	a := strings.Split(strings.TrimSuffix(expected, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+
+	var sb strings.Builder
+	for _, step := range lcsAlign(len(a), len(b), func(i, j int) bool { return a[i] == b[j] }) {
+		switch {
+		case step.b < 0:
+			sb.WriteString("- " + a[step.a] + "\n")
+		case step.a < 0:
+			sb.WriteString("+ " + b[step.b] + "\n")
+		default:
+			sb.WriteString("  " + a[step.a] + "\n")
+		}
+	}
+	return sb.String()
+}
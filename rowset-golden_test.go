@@ -0,0 +1,94 @@
+package sqlrows
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGoldenFixture(t *testing.T, zone *time.Location) MockRowSet {
+	name := "NULL"
+	return newTestCommon(t).
+		HasMockRowSet([]string{
+			"name=ID;type=int64",
+			"name=NAME;type=*string",
+			"name=AT;type=time.Time",
+		}, DbTypePostgresSQL).
+		AddsRows([][]any{
+			{int64(1), &name, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC).In(zone)},
+			{int64(2), nil, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC).In(zone)},
+		}).rs
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "people.golden")
+
+	ft := &fakeT{}
+	assert.False(t, AssertGolden(ft, newGoldenFixture(t, time.UTC), path))
+	assert.Contains(t, ft.messages[0], "run with -update or set SQLROWS_UPDATE_GOLDEN=1 to create it")
+
+	t.Setenv("SQLROWS_UPDATE_GOLDEN", "1")
+	require.True(t, AssertGolden(t, newGoldenFixture(t, time.UTC), path))
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"+------------+----------------------------+--------------------------+\n"+
+		"| ID         | NAME                       | AT                       |\n"+
+		"| BIGINT     | TEXT                       | TIMESTAMP WITH TIME ZONE |\n"+
+		"| int64      | *string                    | time.Time                |\n"+
+		"| nullable=? | nullable=?                 | nullable=?               |\n"+
+		"| length=-   | length=9223372036854775807 | length=-                 |\n"+
+		"| decimal=-  | decimal=-                  | decimal=-                |\n"+
+		"+------------+----------------------------+--------------------------+\n"+
+		"| 1          | \"NULL\"                     | 2024-05-06T07:08:09Z     |\n"+
+		"| 2          | NULL                       | 2024-05-06T00:00:00Z     |\n"+
+		"+------------+----------------------------+--------------------------+\n",
+		string(golden))
+
+	// the same instants in another zone serialize identically
+	t.Setenv("SQLROWS_UPDATE_GOLDEN", "")
+	assert.True(t, AssertGolden(t, newGoldenFixture(t, time.FixedZone("EST", -5*3600)), path))
+}
+
+func TestAssertGoldenUpdateFlag(t *testing.T) {
+	// a test binary defines -update the way this one does here
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "update golden files")
+	}
+	require.NoError(t, flag.Set("update", "true"))
+	t.Cleanup(func() { flag.Set("update", "false") })
+
+	path := filepath.Join(t.TempDir(), "people.golden")
+	require.True(t, AssertGolden(t, newGoldenFixture(t, time.UTC), path))
+	_, err := os.Stat(path)
+	assert.NoError(t, err)
+
+	// an explicit option wins over the flag
+	ft := &fakeT{}
+	assert.False(t, AssertGolden(ft, newGoldenFixture(t, time.UTC), filepath.Join(t.TempDir(), "missing.golden"), UpdateGolden(false)))
+	assert.Len(t, ft.messages, 1)
+}
+
+func TestAssertGoldenMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.golden")
+	require.True(t, AssertGolden(t, newGoldenFixture(t, time.UTC), path, UpdateGolden(true)))
+
+	changed := newGoldenFixture(t, time.UTC)
+	changed.RenameColumn("NAME", "LABEL")
+	ft := &fakeT{}
+	assert.False(t, AssertGolden(ft, changed, path))
+	require.Len(t, ft.messages, 1)
+	assert.Contains(t, ft.messages[0], ""+
+		"- | ID         | NAME                       | AT                       |\n"+
+		"+ | ID         | LABEL                      | AT                       |\n"+
+		"  | BIGINT     | TEXT                       | TIMESTAMP WITH TIME ZONE |\n")
+}
+
+func TestLineDiff(t *testing.T) {
+	assert.Equal(t, "  a\n- b\n+ x\n  c\n+ d\n", lineDiff("a\nb\nc\n", "a\nx\nc\nd\n"))
+}
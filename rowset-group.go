@@ -0,0 +1,505 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Grouping collects the rows of a RowSet by key columns, ready for aggregation
+	Grouping struct {
+		rs        RowSet
+		keys      []string
+		dbType    DatabaseType
+		hasDbType bool
+	}
+
+	// Aggregate is an aggregate function applied to each group
+	Aggregate struct {
+		fn   aggFunc
+		col  string
+		name string
+	}
+
+	aggFunc int
+
+	// numericClass describes an aggregate input column in terms of how databases type its results
+	numericClass int
+
+	aggState struct {
+		count    int64
+		intSum   big.Int // exact, so overflow is caught when converting the result
+		floatSum float64
+		isFloat  bool
+		best     any
+	}
+
+	group struct {
+		key    []any
+		states []aggState
+	}
+)
+
+const (
+	aggCount aggFunc = iota
+	aggCountCol
+	aggSum
+	aggMin
+	aggMax
+	aggAvg
+)
+
+const (
+	classOther numericClass = iota
+	classSmallInt
+	classBigInt
+	classIntDecimal // a NUMBER, NUMERIC or DECIMAL of scale 0, which may be wider than BIGINT
+	classDecimal
+	classFloat
+)
+
+var (
+	int64Type   = reflect.TypeOf(int64(0))
+	float64Type = reflect.TypeOf(float64(0))
+	stringType  = reflect.TypeOf("")
+)
+
+// Groups the rows of [rs] by the [keys] columns. Call Agg to produce the result.
+// With no keys, the whole row set is one group.
+func GroupBy(rs RowSet, keys ...string) *Grouping {
+	return &Grouping{rs: rs, keys: keys}
+}
+
+// Sets the database whose typing rules apply to aggregate results. When not set,
+// the database is taken from the column types of a mock row set.
+func (g *Grouping) Dialect(dbType DatabaseType) *Grouping {
+	g.dbType = dbType
+	g.hasDbType = true
+	return g
+}
+
+// Counts the rows in each group, as COUNT(*) does.
+func Count() Aggregate {
+	return Aggregate{fn: aggCount, name: "COUNT(*)"}
+}
+
+// Counts the non-NULL values of [col] in each group.
+func CountOf(col string) Aggregate {
+	return Aggregate{fn: aggCountCol, col: col, name: "COUNT(" + col + ")"}
+}
+
+// Totals the non-NULL values of [col] in each group.
+func Sum(col string) Aggregate {
+	return Aggregate{fn: aggSum, col: col, name: "SUM(" + col + ")"}
+}
+
+// Finds the smallest non-NULL value of [col] in each group.
+func Min(col string) Aggregate {
+	return Aggregate{fn: aggMin, col: col, name: "MIN(" + col + ")"}
+}
+
+// Finds the largest non-NULL value of [col] in each group.
+func Max(col string) Aggregate {
+	return Aggregate{fn: aggMax, col: col, name: "MAX(" + col + ")"}
+}
+
+// Averages the non-NULL values of [col] in each group.
+func Avg(col string) Aggregate {
+	return Aggregate{fn: aggAvg, col: col, name: "AVG(" + col + ")"}
+}
+
+// Names the result column of the aggregate, as AS does in SQL.
+func (a Aggregate) As(name string) Aggregate {
+	a.name = name
+	return a
+}
+
+// Aggregates each group, producing a row set of the key columns followed by one
+// column per aggregate. Groups appear in the order their first row was read. The
+// input is read in full on the first call to Next, and closed with the result.
+func (g *Grouping) Agg(aggs ...Aggregate) RowSet {
+	src, err := openSource(g.rs)
+	if err != nil {
+		return failedRowSet(err, g.rs)
+	}
+
+	keyIndexes := make([]int, 0, len(g.keys))
+	columns := make([]string, 0, len(g.keys)+len(aggs))
+	colTypes := make([]ColumnType, 0, len(columns))
+	for _, key := range g.keys {
+		index, err := src.index(key)
+		if err != nil {
+			return failedRowSet(err, g.rs)
+		}
+		keyIndexes = append(keyIndexes, index)
+		columns = append(columns, src.columns[index])
+		colTypes = append(colTypes, src.colTypes[index])
+	}
+
+	dbType, hasDbType := g.dbType, g.hasDbType
+	if !hasDbType {
+		for _, ct := range src.colTypes {
+			if dbType, hasDbType = columnDialect(ct); hasDbType {
+				break
+			}
+		}
+	}
+	if !hasDbType {
+		return failedRowSet(errors.New("database type of row set is unknown; call Dialect"), g.rs)
+	}
+
+	aggIndexes := make([]int, len(aggs))
+	resultTypes := make([]*mockColumnType, len(aggs))
+	for i, agg := range aggs {
+		var input ColumnType
+		if agg.fn != aggCount {
+			if aggIndexes[i], err = src.index(agg.col); err != nil {
+				return failedRowSet(err, g.rs)
+			}
+			input = src.colTypes[aggIndexes[i]]
+		}
+		if resultTypes[i], err = aggregateColumnType(dbType, agg, input); err != nil {
+			return failedRowSet(err, g.rs)
+		}
+		columns = append(columns, agg.name)
+		colTypes = append(colTypes, resultTypes[i])
+	}
+
+	var groups []*group
+	loaded := false
+	return &derivedRowSet{
+		columns:  columns,
+		colTypes: colTypes,
+		closer:   g.rs.Close,
+		pull: func() ([]any, error) {
+			if !loaded {
+				loaded = true
+				if groups, err = g.collect(src, keyIndexes, aggs, aggIndexes); err != nil {
+					return nil, err
+				}
+			}
+
+			if len(groups) == 0 {
+				return nil, nil
+			}
+			grp := groups[0]
+			groups = groups[1:]
+
+			row := append(make([]any, 0, len(columns)), grp.key...)
+			for i, agg := range aggs {
+				val, err := grp.states[i].result(agg.fn, resultTypes[i])
+				if err != nil {
+					return nil, err
+				}
+				row = append(row, val)
+			}
+			return row, nil
+		},
+	}
+}
+
+func (g *Grouping) collect(src *sourceRows, keyIndexes []int, aggs []Aggregate, aggIndexes []int) ([]*group, error) {
+	var groups []*group
+	lookup := map[string]*group{}
+	for {
+		row, err := src.next()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			break
+		}
+
+		key := make([]any, len(keyIndexes))
+		for i, index := range keyIndexes {
+			key[i] = row[index]
+		}
+		hash := rowKey(key)
+		grp := lookup[hash]
+		if grp == nil {
+			grp = &group{key: key, states: make([]aggState, len(aggs))}
+			lookup[hash] = grp
+			groups = append(groups, grp)
+		}
+
+		for i, agg := range aggs {
+			if agg.fn == aggCount {
+				grp.states[i].count++
+				continue
+			}
+			if err := grp.states[i].accumulate(agg, row[aggIndexes[i]]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// like SQL, an aggregate without GROUP BY produces a row even for no input
+	if len(groups) == 0 && len(keyIndexes) == 0 {
+		groups = append(groups, &group{states: make([]aggState, len(aggs))})
+	}
+	return groups, nil
+}
+
+func (st *aggState) accumulate(agg Aggregate, val any) error {
+	val = normalizeValue(val)
+	if val == nil {
+		return nil
+	}
+	st.count++
+
+	switch agg.fn {
+	case aggSum, aggAvg:
+		rv := reflect.ValueOf(val)
+		if rv.Kind() == reflect.String || isBytesKind(rv.Type()) {
+			// drivers deliver decimals as text; whole numbers are summed exactly
+			text := valueString(val)
+			if n, ok := new(big.Int).SetString(text, 10); ok && !st.isFloat {
+				st.intSum.Add(&st.intSum, n)
+				return nil
+			}
+			f, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return fmt.Errorf("cannot aggregate non-numeric value %q in column %s", text, agg.col)
+			}
+			val = f
+			rv = reflect.ValueOf(f)
+		}
+		switch {
+		case rv.CanInt() && !st.isFloat:
+			st.intSum.Add(&st.intSum, big.NewInt(rv.Int()))
+		case rv.CanUint() && !st.isFloat:
+			st.intSum.Add(&st.intSum, new(big.Int).SetUint64(rv.Uint()))
+		default:
+			f, ok := numericValue(val)
+			if !ok {
+				return fmt.Errorf("cannot aggregate non-numeric value of type %T in column %s", val, agg.col)
+			}
+			if !st.isFloat {
+				st.isFloat = true
+				st.floatSum = bigFloat(&st.intSum)
+			}
+			st.floatSum += f
+		}
+	case aggMin:
+		if st.best == nil || compareValues(val, st.best) < 0 {
+			st.best = val
+		}
+	case aggMax:
+		if st.best == nil || compareValues(val, st.best) > 0 {
+			st.best = val
+		}
+	}
+	return nil
+}
+
+func (st *aggState) result(fn aggFunc, ct *mockColumnType) (any, error) {
+	switch fn {
+	case aggCount, aggCountCol:
+		return convertIntResult(big.NewInt(st.count), ct)
+	case aggMin, aggMax:
+		return st.best, nil
+	}
+
+	if st.count == 0 {
+		return nil, nil
+	}
+
+	if !st.isFloat {
+		if fn == aggSum {
+			return convertIntResult(&st.intSum, ct)
+		}
+		if isIntKind(ct.colType.Elem().Kind()) {
+			// integer averages truncate, as in MS SQL
+			return convertIntResult(new(big.Int).Quo(&st.intSum, big.NewInt(st.count)), ct)
+		}
+	}
+
+	total := st.floatSum
+	if !st.isFloat {
+		total = bigFloat(&st.intSum)
+	}
+	if fn == aggAvg {
+		total /= float64(st.count)
+	}
+	if ct.scale > 0 {
+		scale := math.Pow10(int(ct.scale))
+		total = math.Round(total*scale) / scale
+	}
+	return convertResult(total, ct), nil
+}
+
+// convertIntResult stores an exact integer as the Go type of the result column,
+// failing as the database does when the value does not fit
+func convertIntResult(n *big.Int, ct *mockColumnType) (any, error) {
+	goType := ct.colType
+	if goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	if goType.Kind() == reflect.String {
+		return n.String(), nil
+	}
+	if !isIntKind(goType.Kind()) {
+		return convertResult(bigFloat(n), ct), nil
+	}
+
+	rv := reflect.New(goType).Elem()
+	if !n.IsInt64() || rv.OverflowInt(n.Int64()) {
+		if ct.dbType == DbTypeMsSQL {
+			return nil, fmt.Errorf("arithmetic overflow error converting expression to data type %s", strings.ToLower(ct.databaseType))
+		}
+		return nil, fmt.Errorf("%s: %s is out of range for %s", ct.colName, n, goType)
+	}
+	rv.SetInt(n.Int64())
+	return rv.Interface(), nil
+}
+
+// convertResult stores a computed value as the Go type of the result column
+func convertResult(val any, ct *mockColumnType) any {
+	goType := ct.colType
+	if goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	if f, ok := val.(float64); ok && goType.Kind() == reflect.String {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return reflect.ValueOf(val).Convert(goType).Interface()
+}
+
+func bigFloat(n *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(n).Float64()
+	return f
+}
+
+// aggregateColumnType applies the typing rules of [dbType] to an aggregate of [input]
+func aggregateColumnType(dbType DatabaseType, agg Aggregate, input ColumnType) (*mockColumnType, error) {
+	ct := &mockColumnType{
+		colName: agg.name,
+		dbType:  dbType,
+	}
+
+	if agg.fn == aggCount || agg.fn == aggCountCol {
+		ct.colType = int64Type
+		switch dbType {
+		case DbTypeSnowflake:
+			ct.databaseType, ct.precision = "NUMBER", 18
+		case DbTypePostgresSQL:
+			ct.databaseType = "BIGINT"
+		case DbTypeMsSQL:
+			ct.colType, ct.databaseType = reflect.TypeOf(int32(0)), "INT"
+		default:
+			return nil, errors.New("invalid database type")
+		}
+		return ct, nil
+	}
+
+	ct.nullable = true
+	if agg.fn == aggMin || agg.fn == aggMax {
+		ct.colType = input.ScanType()
+		if ct.colType != nil && ct.colType.Kind() != reflect.Pointer {
+			ct.colType = reflect.PointerTo(ct.colType)
+		}
+		ct.databaseType = input.DatabaseTypeName()
+		ct.length, _ = input.Length()
+		ct.precision, ct.scale, _ = input.DecimalSize()
+		return ct, nil
+	}
+
+	class := classifyNumeric(input)
+	if class == classOther {
+		return nil, fmt.Errorf("cannot aggregate column %s of type %s", input.Name(), input.DatabaseTypeName())
+	}
+	_, scale, _ := input.DecimalSize()
+	goType := float64Type
+	switch dbType {
+	case DbTypeSnowflake:
+		switch {
+		case class == classFloat:
+			ct.databaseType = "FLOAT"
+		case agg.fn == aggSum:
+			ct.databaseType, ct.precision, ct.scale = "NUMBER", 38, scale
+			if scale == 0 {
+				goType = int64Type
+			}
+		default:
+			ct.databaseType, ct.precision, ct.scale = "NUMBER", 38, max(scale, 6)
+		}
+
+	case DbTypePostgresSQL:
+		switch {
+		case class == classFloat && agg.fn == aggSum && baseScanType(input) == reflect.TypeOf(float32(0)):
+			ct.databaseType, goType = "REAL", reflect.TypeOf(float32(0))
+		case class == classFloat && agg.fn == aggSum:
+			ct.databaseType = "DOUBLE PRECISION"
+		case class == classFloat:
+			ct.databaseType = "DOUBLE PRECISION"
+		case agg.fn == aggSum && class == classSmallInt:
+			ct.databaseType, goType = "BIGINT", int64Type
+		case agg.fn == aggSum && class == classBigInt:
+			ct.databaseType, goType = "NUMERIC", int64Type
+		default:
+			ct.databaseType = "NUMERIC"
+		}
+
+	case DbTypeMsSQL:
+		switch class {
+		case classFloat:
+			ct.databaseType = "FLOAT"
+		case classSmallInt:
+			ct.databaseType, goType = "INT", reflect.TypeOf(int32(0))
+		case classBigInt:
+			ct.databaseType, goType = "BIGINT", int64Type
+		default:
+			ct.databaseType, ct.precision, ct.scale = "DECIMAL", 38, scale
+			if agg.fn == aggAvg {
+				ct.scale = max(scale, 6)
+			}
+		}
+
+	default:
+		return nil, errors.New("invalid database type")
+	}
+
+	// a sum of whole decimals can outgrow int64, so it comes back as decimal text
+	if agg.fn == aggSum && class == classIntDecimal {
+		goType = stringType
+	}
+
+	ct.colType = reflect.PointerTo(goType)
+	return ct, nil
+}
+
+// classifyNumeric buckets a column by its database type name, falling back to its Go type
+func classifyNumeric(ct ColumnType) numericClass {
+	switch baseDbTypeName(ct.DatabaseTypeName()) {
+	case "TINYINT", "SMALLINT", "INT", "INT2", "INT4", "INTEGER":
+		return classSmallInt
+	case "BIGINT", "INT8":
+		return classBigInt
+	case "NUMBER", "NUMERIC", "DECIMAL", "FIXED":
+		if _, scale, _ := ct.DecimalSize(); scale > 0 {
+			return classDecimal
+		}
+		return classIntDecimal
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION":
+		return classFloat
+	}
+
+	scanType := baseScanType(ct)
+	if scanType == nil {
+		return classOther
+	}
+	switch scanType.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return classSmallInt
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return classBigInt
+	case reflect.Float32, reflect.Float64:
+		return classFloat
+	}
+	return classOther
+}
@@ -0,0 +1,183 @@
+package sqlrows
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSalesFixture(t *testing.T, dbType DatabaseType) MockRowSet {
+	amount := func(v float64) *float64 { return &v }
+	return newTestCommon(t).
+		HasMockRowSet([]string{
+			"name=REGION;type=string",
+			"name=QTY;type=int32",
+			"name=UNITS;type=int64",
+			"name=AMOUNT;type=*float64;precision=10;scale=2;dbType=DECIMAL",
+		}, dbType).
+		AddsRows([][]any{
+			{"east", int32(3), int64(30), amount(10.10)},
+			{"west", int32(1), int64(10), amount(5.25)},
+			{"east", int32(2), int64(20), nil},
+			{"east", int32(2), int64(25), amount(0.20)},
+		}).rs
+}
+
+func TestGroupByAgg(t *testing.T) {
+	it := newTestCommon(t)
+	rs := GroupBy(newSalesFixture(t, DbTypeSnowflake), "region").
+		Agg(Count(), Sum("QTY"), Min("amount"), Max("amount"), Avg("amount").As("AVG_AMOUNT"), CountOf("amount"))
+
+	it.VerifiesRows(rs, []string{"REGION", "COUNT(*)", "SUM(QTY)", "MIN(amount)", "MAX(amount)", "AVG_AMOUNT", "COUNT(amount)"},
+		[]any{"east", int64(3), int64(7), 0.20, 10.10, 5.15, int64(2)},
+		[]any{"west", int64(1), int64(1), 5.25, 5.25, 5.25, int64(1)},
+	)
+}
+
+func TestGroupByNoKeys(t *testing.T) {
+	it := newTestCommon(t)
+	rs := GroupBy(newSalesFixture(t, DbTypePostgresSQL)).Agg(Count(), Sum("units"))
+	it.VerifiesRows(rs, []string{"COUNT(*)", "SUM(units)"}, []any{int64(4), int64(85)})
+
+	empty := NewMockRowSet([]string{"name=UNITS;type=int64"}, DbTypePostgresSQL)
+	rs = GroupBy(empty).Agg(Count(), Sum("units"))
+	it.VerifiesRows(rs, []string{"COUNT(*)", "SUM(units)"}, []any{int64(0), nil})
+}
+
+func TestGroupByMsSqlIntegerAverage(t *testing.T) {
+	it := newTestCommon(t)
+	rs := GroupBy(newSalesFixture(t, DbTypeMsSQL), "REGION").Agg(Avg("QTY"), Sum("UNITS"))
+	it.VerifiesRows(rs, []string{"REGION", "AVG(QTY)", "SUM(UNITS)"},
+		[]any{"east", int32(2), int64(75)},
+		[]any{"west", int32(1), int64(10)},
+	)
+}
+
+type aggTypeCase struct {
+	agg       Aggregate
+	goType    reflect.Type
+	dbType    string
+	precision int64
+	scale     int64
+}
+
+func verifyAggTypes(t *testing.T, dbType DatabaseType, cases []aggTypeCase) {
+	aggs := make([]Aggregate, 0, len(cases))
+	for _, c := range cases {
+		aggs = append(aggs, c.agg)
+	}
+	rs := GroupBy(newSalesFixture(t, dbType)).Agg(aggs...)
+	require.NoError(t, rs.Err())
+
+	colTypes, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	require.Len(t, colTypes, len(cases))
+	for i, c := range cases {
+		assert.Equal(t, c.goType, colTypes[i].ScanType(), "%s scan type", c.agg.name)
+		assert.Equal(t, c.dbType, colTypes[i].DatabaseTypeName(), "%s database type", c.agg.name)
+		precision, scale, _ := colTypes[i].DecimalSize()
+		assert.Equal(t, c.precision, precision, "%s precision", c.agg.name)
+		assert.Equal(t, c.scale, scale, "%s scale", c.agg.name)
+	}
+}
+
+func TestAggregateTypesSnowflake(t *testing.T) {
+	verifyAggTypes(t, DbTypeSnowflake, []aggTypeCase{
+		{Count(), reflect.TypeOf(int64(0)), "NUMBER", 18, 0},
+		{Sum("QTY"), reflect.TypeOf(new(int64)), "NUMBER", 38, 0},
+		{Sum("AMOUNT"), reflect.TypeOf(new(float64)), "NUMBER", 38, 2},
+		{Avg("UNITS"), reflect.TypeOf(new(float64)), "NUMBER", 38, 6},
+		{Max("AMOUNT"), reflect.TypeOf(new(float64)), "DECIMAL", 10, 2},
+	})
+}
+
+func TestAggregateTypesPostgres(t *testing.T) {
+	verifyAggTypes(t, DbTypePostgresSQL, []aggTypeCase{
+		{Count(), reflect.TypeOf(int64(0)), "BIGINT", 0, 0},
+		{Sum("QTY"), reflect.TypeOf(new(int64)), "BIGINT", 0, 0},
+		// lib/pq decodes the missing type modifier of NUMERIC to these
+		{Sum("UNITS"), reflect.TypeOf(new(int64)), "NUMERIC", 65535, 65531},
+		{Avg("UNITS"), reflect.TypeOf(new(float64)), "NUMERIC", 65535, 65531},
+		{Min("QTY"), reflect.TypeOf(new(int32)), "INTEGER", 0, 0},
+	})
+}
+
+func TestAggregateTypesMsSql(t *testing.T) {
+	verifyAggTypes(t, DbTypeMsSQL, []aggTypeCase{
+		{Count(), reflect.TypeOf(int32(0)), "INT", 0, 0},
+		{Sum("QTY"), reflect.TypeOf(new(int32)), "INT", 0, 0},
+		{Sum("UNITS"), reflect.TypeOf(new(int64)), "BIGINT", 0, 0},
+		{Avg("AMOUNT"), reflect.TypeOf(new(float64)), "DECIMAL", 38, 6},
+	})
+}
+
+func TestGroupByErrors(t *testing.T) {
+	rs := GroupBy(newSalesFixture(t, DbTypeSnowflake), "XYZ").Agg(Count())
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "column XYZ does not exist")
+
+	rs = GroupBy(newSalesFixture(t, DbTypeSnowflake)).Agg(Sum("REGION"))
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "cannot aggregate column REGION of type VARCHAR")
+
+	rs = GroupBy(&errRowSet{RowSet: Project(newSalesFixture(t, DbTypeSnowflake), "QTY")}).Dialect(DbTypePostgresSQL).Agg(Sum("QTY"))
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "connection reset")
+}
+
+func TestGroupBySumOverflow(t *testing.T) {
+	ints := NewMockRowSet([]string{"name=QTY;type=int32;dbType=INT"}, DbTypeMsSQL)
+	ints.AddRow([]any{int32(math.MaxInt32)})
+	ints.AddRow([]any{int32(1)})
+	rs := GroupBy(ints).Agg(Sum("QTY"))
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "arithmetic overflow error converting expression to data type int")
+
+	bigints := NewMockRowSet([]string{"name=UNITS;type=int64"}, DbTypeSnowflake)
+	bigints.AddRow([]any{int64(math.MaxInt64)})
+	bigints.AddRow([]any{int64(1)})
+	rs = GroupBy(bigints).Agg(Sum("UNITS"))
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "SUM(UNITS): 9223372036854775808 is out of range for int64")
+
+	// an intermediate sum past int64 is fine while the total fits
+	bigints.Rewind()
+	bigints.AddRow([]any{int64(math.MinInt64)})
+	it := newTestCommon(t)
+	it.VerifiesRows(GroupBy(bigints).Agg(Sum("UNITS"), Avg("UNITS")), []string{"SUM(UNITS)", "AVG(UNITS)"},
+		[]any{int64(0), 0.0})
+}
+
+func TestGroupBySumWholeDecimals(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=string;dbType=NUMBER(38,0)"}, DbTypeSnowflake).
+		AddsRows([][]any{{"9223372036854775807"}, {"9223372036854775807"}, {"2"}})
+	rs := GroupBy(it.rs).Agg(Sum("ID"))
+
+	colTypes, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	assert.Equal(t, reflect.TypeOf(new(string)), colTypes[0].ScanType())
+	precision, scale, ok := colTypes[0].DecimalSize()
+	assert.Equal(t, []any{int64(38), int64(0), true}, []any{precision, scale, ok})
+	it.VerifiesRows(rs, []string{"SUM(ID)"}, []any{"18446744073709551616"})
+
+	it = newTestCommon(t).
+		HasMockRowSet([]string{"name=TOTAL;type=int64;dbType=DECIMAL(20,0)"}, DbTypeMsSQL).
+		AddsRows([][]any{{int64(math.MaxInt64)}, {int64(1)}})
+	rs = GroupBy(it.rs).Agg(Sum("TOTAL"), Avg("TOTAL"))
+	colTypes, err = rs.ColumnTypes()
+	require.NoError(t, err)
+	assert.Equal(t, "DECIMAL", colTypes[0].DatabaseTypeName())
+	it.VerifiesRows(rs, []string{"SUM(TOTAL)", "AVG(TOTAL)"}, []any{"9223372036854775808", 4.611686018427388e+18})
+}
+
+func TestGroupByCountOverflowMsSql(t *testing.T) {
+	st := aggState{count: math.MaxInt32 + 1}
+	ct, err := aggregateColumnType(DbTypeMsSQL, Count(), nil)
+	require.NoError(t, err)
+	_, err = st.result(aggCount, ct)
+	assert.EqualError(t, err, "arithmetic overflow error converting expression to data type int")
+}
@@ -0,0 +1,315 @@
+package sqlrows
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type (
+	// JoinCondition decides which rows of the right input pair with a row of the left
+	JoinCondition struct {
+		leftCols  []string
+		rightCols []string
+		match     func(left, right map[string]any) bool
+	}
+
+	JoinOption func(cfg *joinConfig)
+
+	joinConfig struct {
+		leftPrefix  string
+		rightPrefix string
+	}
+
+	// columnTypeOverride reports a different name or nullability than the column type it wraps
+	columnTypeOverride struct {
+		ColumnType
+		name     string
+		nullable bool
+	}
+)
+
+// Joins rows where column [leftCol] of the left input equals column [rightCol] of
+// the right input. As in SQL, NULL never equals anything. The columns must have
+// compatible types.
+func On(leftCol, rightCol string) JoinCondition {
+	return JoinCondition{leftCols: []string{leftCol}, rightCols: []string{rightCol}}
+}
+
+// Joins rows for which [match] returns true. The row maps are keyed by the
+// column names of each input.
+func OnFunc(match func(left, right map[string]any) bool) JoinCondition {
+	return JoinCondition{match: match}
+}
+
+// Combines two join conditions; rows must satisfy both.
+func (jc JoinCondition) And(other JoinCondition) JoinCondition {
+	combined := JoinCondition{
+		leftCols:  append(append([]string{}, jc.leftCols...), other.leftCols...),
+		rightCols: append(append([]string{}, jc.rightCols...), other.rightCols...),
+		match:     jc.match,
+	}
+	if other.match != nil {
+		if combined.match == nil {
+			combined.match = other.match
+		} else {
+			combined.match = func(left, right map[string]any) bool {
+				return jc.match(left, right) && other.match(left, right)
+			}
+		}
+	}
+	return combined
+}
+
+// Sets the prefixes given to column names that appear in both inputs of a join.
+// The defaults are "left_" and "right_", so joining two tables that each have an
+// ID column produces left_ID and right_ID.
+func JoinPrefixes(left, right string) JoinOption {
+	return func(cfg *joinConfig) {
+		cfg.leftPrefix = left
+		cfg.rightPrefix = right
+	}
+}
+
+// Returns the distinct rows of all inputs, as SQL UNION does. Inputs must have the
+// same number of columns with compatible types; names come from the first input.
+func Union(sets ...RowSet) RowSet {
+	return Distinct(UnionAll(sets...))
+}
+
+// Returns all rows of each input in turn, as SQL UNION ALL does. Inputs must have
+// the same number of columns with compatible types; names come from the first input.
+// Rows are streamed, and closing the result closes every input.
+func UnionAll(sets ...RowSet) RowSet {
+	if len(sets) == 0 {
+		return failedRowSet(fmt.Errorf("union requires at least one row set"))
+	}
+
+	sources := make([]*sourceRows, 0, len(sets))
+	for _, rs := range sets {
+		src, err := openSource(rs)
+		if err != nil {
+			return failedRowSet(err, sets...)
+		}
+		sources = append(sources, src)
+	}
+
+	first := sources[0]
+	colTypes := make([]ColumnType, len(first.colTypes))
+	for i, ct := range first.colTypes {
+		nullable, _ := ct.Nullable()
+		for n, src := range sources[1:] {
+			if len(src.columns) != len(first.columns) {
+				return failedRowSet(fmt.Errorf("union input %d has %d columns, expected %d", n+1, len(src.columns), len(first.columns)), sets...)
+			}
+			if err := checkCompatible(ct, src.colTypes[i]); err != nil {
+				return failedRowSet(fmt.Errorf("union input %d: %w", n+1, err), sets...)
+			}
+			other, _ := src.colTypes[i].Nullable()
+			nullable = nullable || other
+		}
+		colTypes[i] = withOverride(ct, ct.Name(), nullable)
+	}
+
+	current := 0
+	return &derivedRowSet{
+		columns:  first.columns,
+		colTypes: colTypes,
+		closer:   closeAll(sets),
+		pull: func() ([]any, error) {
+			for current < len(sources) {
+				row, err := sources[current].next()
+				if err != nil || row != nil {
+					return row, err
+				}
+				current++
+			}
+			return nil, nil
+		},
+	}
+}
+
+// Returns each pairing of left and right rows that satisfies [on]. Left rows are
+// streamed; the right input is read in full on the first call to Next.
+func InnerJoin(left, right RowSet, on JoinCondition, opts ...JoinOption) RowSet {
+	return join(left, right, on, false, opts)
+}
+
+// Returns each pairing of left and right rows that satisfies [on], plus left rows
+// without a match, paired with NULLs. Columns from the right input become nullable.
+func LeftJoin(left, right RowSet, on JoinCondition, opts ...JoinOption) RowSet {
+	return join(left, right, on, true, opts)
+}
+
+func join(left, right RowSet, on JoinCondition, outer bool, opts []JoinOption) RowSet {
+	cfg := joinConfig{leftPrefix: "left_", rightPrefix: "right_"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	lsrc, err := openSource(left)
+	if err != nil {
+		return failedRowSet(err, left, right)
+	}
+	rsrc, err := openSource(right)
+	if err != nil {
+		return failedRowSet(err, left, right)
+	}
+
+	// resolve the equi-join columns
+	leftKeys := make([]int, len(on.leftCols))
+	rightKeys := make([]int, len(on.rightCols))
+	for i := range on.leftCols {
+		if leftKeys[i], err = lsrc.index(on.leftCols[i]); err != nil {
+			return failedRowSet(err, left, right)
+		}
+		if rightKeys[i], err = rsrc.index(on.rightCols[i]); err != nil {
+			return failedRowSet(err, left, right)
+		}
+		if err = checkCompatible(lsrc.colTypes[leftKeys[i]], rsrc.colTypes[rightKeys[i]]); err != nil {
+			return failedRowSet(err, left, right)
+		}
+	}
+
+	// name the output columns, prefixing any that appear on both sides
+	shared := map[string]struct{}{}
+	for _, col := range rsrc.columns {
+		if _, found := lsrc.lookup[strings.ToLower(col)]; found {
+			shared[strings.ToLower(col)] = struct{}{}
+		}
+	}
+	columns := make([]string, 0, len(lsrc.columns)+len(rsrc.columns))
+	colTypes := make([]ColumnType, 0, len(columns))
+	addColumns := func(src *sourceRows, prefix string, nullable bool) {
+		for i, col := range src.columns {
+			name := col
+			if _, dup := shared[strings.ToLower(col)]; dup {
+				name = prefix + col
+			}
+			isNullable, _ := src.colTypes[i].Nullable()
+			columns = append(columns, name)
+			colTypes = append(colTypes, withOverride(src.colTypes[i], name, isNullable || nullable))
+		}
+	}
+	addColumns(lsrc, cfg.leftPrefix, false)
+	addColumns(rsrc, cfg.rightPrefix, outer)
+
+	matches := func(lrow, rrow []any) bool {
+		for i := range leftKeys {
+			lv := normalizeValue(lrow[leftKeys[i]])
+			rv := normalizeValue(rrow[rightKeys[i]])
+			if lv == nil || rv == nil || compareValues(lv, rv) != 0 {
+				return false
+			}
+		}
+		return on.match == nil || on.match(lsrc.rowMap(lrow), rsrc.rowMap(rrow))
+	}
+
+	var rightRows [][]any
+	var pending [][]any
+	var current []any
+	loaded := false
+	return &derivedRowSet{
+		columns:  columns,
+		colTypes: colTypes,
+		closer:   closeAll([]RowSet{left, right}),
+		pull: func() ([]any, error) {
+			if !loaded {
+				loaded = true
+				if rightRows, err = rsrc.readAll(); err != nil {
+					return nil, err
+				}
+			}
+
+			for len(pending) == 0 {
+				lrow, err := lsrc.next()
+				if lrow == nil || err != nil {
+					return nil, err
+				}
+				current = lrow
+				for _, rrow := range rightRows {
+					if matches(lrow, rrow) {
+						pending = append(pending, rrow)
+					}
+				}
+				if len(pending) == 0 && outer {
+					pending = append(pending, make([]any, len(rsrc.columns)))
+				}
+			}
+
+			rrow := pending[0]
+			pending = pending[1:]
+			return append(append(make([]any, 0, len(columns)), current...), rrow...), nil
+		},
+	}
+}
+
+// checkCompatible verifies two columns hold the same kind of value, ignoring
+// nullability and type parameters such as length
+func checkCompatible(a, b ColumnType) error {
+	aScan, bScan := baseScanType(a), baseScanType(b)
+	if aScan != nil && bScan != nil && aScan != bScan {
+		return fmt.Errorf("column %s scan type %v is incompatible with column %s scan type %v", a.Name(), a.ScanType(), b.Name(), b.ScanType())
+	}
+
+	aDb, bDb := baseDbTypeName(a.DatabaseTypeName()), baseDbTypeName(b.DatabaseTypeName())
+	if aDb != "" && bDb != "" && aDb != bDb {
+		return fmt.Errorf("column %s type %s is incompatible with column %s type %s", a.Name(), a.DatabaseTypeName(), b.Name(), b.DatabaseTypeName())
+	}
+	return nil
+}
+
+func baseScanType(ct ColumnType) reflect.Type {
+	t := ct.ScanType()
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// baseDbTypeName strips parameters from a type name, so NUMBER(10,2) becomes NUMBER
+func baseDbTypeName(name string) string {
+	if index := strings.IndexByte(name, '('); index >= 0 {
+		name = name[:index]
+	}
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// withOverride wraps [ct] when the name or nullability must change
+func withOverride(ct ColumnType, name string, nullable bool) ColumnType {
+	isNullable, _ := ct.Nullable()
+	if name == ct.Name() && nullable == isNullable {
+		return ct
+	}
+	return &columnTypeOverride{ColumnType: ct, name: name, nullable: nullable}
+}
+
+func (c *columnTypeOverride) Name() string {
+	return c.name
+}
+
+func (c *columnTypeOverride) Nullable() (nullable bool, ok bool) {
+	return c.nullable, true
+}
+
+// ScanType follows the mock convention of pointer types for columns made nullable
+func (c *columnTypeOverride) ScanType() reflect.Type {
+	t := c.ColumnType.ScanType()
+	wasNullable, _ := c.ColumnType.Nullable()
+	if c.nullable && !wasNullable && t != nil && t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		return reflect.PointerTo(t)
+	}
+	return t
+}
+
+func (c *columnTypeOverride) dialect() (DatabaseType, bool) {
+	return columnDialect(c.ColumnType)
+}
+
+// columnDialect reports the database of a column type created by this package
+func columnDialect(ct ColumnType) (DatabaseType, bool) {
+	if d, ok := ct.(interface{ dialect() (DatabaseType, bool) }); ok {
+		return d.dialect()
+	}
+	return 0, false
+}
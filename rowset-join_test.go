@@ -0,0 +1,114 @@
+package sqlrows
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCustomersFixture(t *testing.T, dbType DatabaseType) MockRowSet {
+	return newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=NAME;type=string"}, dbType).
+		AddsRows([][]any{
+			{int64(1), "Ann"},
+			{int64(2), "Bob"},
+			{int64(3), "Cy"},
+		}).rs
+}
+
+func newInvoicesFixture(t *testing.T, dbType DatabaseType) MockRowSet {
+	return newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=CUSTOMER_ID;type=int64", "name=TOTAL;type=float64"}, dbType).
+		AddsRows([][]any{
+			{int64(10), int64(1), 5.0},
+			{int64(11), int64(1), 7.5},
+			{int64(12), int64(3), 1.0},
+		}).rs
+}
+
+func TestUnionAll(t *testing.T) {
+	it := newTestCommon(t)
+	rs := UnionAll(newCustomersFixture(t, DbTypeSnowflake), newCustomersFixture(t, DbTypeSnowflake))
+
+	it.VerifiesRows(rs, []string{"ID", "NAME"},
+		[]any{int64(1), "Ann"}, []any{int64(2), "Bob"}, []any{int64(3), "Cy"},
+		[]any{int64(1), "Ann"}, []any{int64(2), "Bob"}, []any{int64(3), "Cy"},
+	)
+}
+
+func TestUnion(t *testing.T) {
+	it := newTestCommon(t)
+	extra := NewMockRowSet([]string{"name=CUST_ID;type=*int64", "name=CUST_NAME;type=string;length=20"}, DbTypeSnowflake)
+	extra.AddRow([]any{nil, "Dee"})
+	extra.AddRow([]any{int64(2), "Bob"})
+
+	rs := Union(newCustomersFixture(t, DbTypeSnowflake), extra)
+
+	colTypes, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	nullable, ok := colTypes[0].Nullable()
+	assert.True(t, nullable && ok, "ID should become nullable")
+	assert.Equal(t, reflect.PointerTo(reflect.TypeOf(int64(0))), colTypes[0].ScanType())
+
+	it.VerifiesRows(rs, []string{"ID", "NAME"},
+		[]any{int64(1), "Ann"}, []any{int64(2), "Bob"}, []any{int64(3), "Cy"},
+		[]any{nil, "Dee"},
+	)
+}
+
+func TestUnionIncompatible(t *testing.T) {
+	other := NewMockRowSet([]string{"name=ID;type=string", "name=NAME;type=string"}, DbTypeSnowflake)
+	rs := UnionAll(newCustomersFixture(t, DbTypeSnowflake), other)
+
+	assert.False(t, rs.Next())
+	assert.ErrorContains(t, rs.Err(), "union input 1: column ID scan type int64 is incompatible")
+
+	short := NewMockRowSet([]string{"name=ID;type=int64"}, DbTypeSnowflake)
+	rs = UnionAll(newCustomersFixture(t, DbTypeSnowflake), short)
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "union input 1 has 1 columns, expected 2")
+}
+
+func TestInnerJoin(t *testing.T) {
+	it := newTestCommon(t)
+	rs := InnerJoin(newCustomersFixture(t, DbTypePostgresSQL), newInvoicesFixture(t, DbTypePostgresSQL), On("ID", "customer_id"))
+
+	it.VerifiesRows(rs, []string{"left_ID", "NAME", "right_ID", "CUSTOMER_ID", "TOTAL"},
+		[]any{int64(1), "Ann", int64(10), int64(1), 5.0},
+		[]any{int64(1), "Ann", int64(11), int64(1), 7.5},
+		[]any{int64(3), "Cy", int64(12), int64(3), 1.0},
+	)
+}
+
+func TestLeftJoin(t *testing.T) {
+	it := newTestCommon(t)
+	on := On("ID", "CUSTOMER_ID").And(OnFunc(func(left, right map[string]any) bool {
+		return right["TOTAL"].(float64) > 2
+	}))
+	rs := LeftJoin(newCustomersFixture(t, DbTypeMsSQL), newInvoicesFixture(t, DbTypeMsSQL), on, JoinPrefixes("c.", "i."))
+
+	colTypes, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	for i, ct := range colTypes {
+		nullable, _ := ct.Nullable()
+		assert.Equal(t, i >= 2, nullable, "column %s nullability", ct.Name())
+	}
+	assert.Equal(t, reflect.PointerTo(reflect.TypeOf(0.0)), colTypes[4].ScanType())
+	assert.Equal(t, "FLOAT", colTypes[4].DatabaseTypeName())
+
+	it.VerifiesRows(rs, []string{"c.ID", "NAME", "i.ID", "CUSTOMER_ID", "TOTAL"},
+		[]any{int64(1), "Ann", int64(10), int64(1), 5.0},
+		[]any{int64(1), "Ann", int64(11), int64(1), 7.5},
+		[]any{int64(2), "Bob", nil, nil, nil},
+		[]any{int64(3), "Cy", nil, nil, nil},
+	)
+}
+
+func TestJoinIncompatibleKeys(t *testing.T) {
+	rs := InnerJoin(newCustomersFixture(t, DbTypeSnowflake), newInvoicesFixture(t, DbTypeSnowflake), On("NAME", "CUSTOMER_ID"))
+
+	assert.False(t, rs.Next())
+	assert.ErrorContains(t, rs.Err(), "column NAME scan type string is incompatible with column CUSTOMER_ID scan type int64")
+}
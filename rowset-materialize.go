@@ -0,0 +1,187 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	MaterializeOption func(cfg *materializeConfig)
+
+	materializeConfig struct {
+		maxBytes  int64
+		maxRows   int
+		dbType    DatabaseType
+		hasDbType bool
+	}
+)
+
+// ErrMaterializeLimit is returned, wrapped, when a row set exceeds a MaxBytes or MaxRows limit.
+var ErrMaterializeLimit = errors.New("materialize limit exceeded")
+
+// Stops Materialize with ErrMaterializeLimit once the rows read are estimated to
+// occupy more than [bytes] of memory.
+func MaxBytes(bytes int64) MaterializeOption {
+	return func(cfg *materializeConfig) {
+		cfg.maxBytes = bytes
+	}
+}
+
+// Stops Materialize with ErrMaterializeLimit if the row set has more than [rows] rows.
+func MaxRows(rows int) MaterializeOption {
+	return func(cfg *materializeConfig) {
+		cfg.maxRows = rows
+	}
+}
+
+// Sets the database of the materialized mock, which governs columns added to it
+// later and the typing rules of GroupBy. When not set, the database is taken
+// from mock column types; if there are none, the copied columns have no known
+// database, so GroupBy asks for Dialect, and columns added later follow
+// Snowflake.
+func MaterializeDialect(dbType DatabaseType) MaterializeOption {
+	return func(cfg *materializeConfig) {
+		cfg.dbType = dbType
+		cfg.hasDbType = true
+	}
+}
+
+// Reads all rows of [rs] into a mock row set that can be rewound and read again,
+// copying the column metadata. [rs] is closed once drained.
+func Materialize(rs RowSet, opts ...MaterializeOption) (MockRowSet, error) {
+	var cfg materializeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	src, err := openSource(rs)
+	if err != nil {
+		return nil, errors.Join(err, rs.Close())
+	}
+
+	if !cfg.hasDbType {
+		for _, ct := range src.colTypes {
+			if cfg.dbType, cfg.hasDbType = columnDialect(ct); cfg.hasDbType {
+				break
+			}
+		}
+	}
+
+	set := &mockRowSet{
+		dbType:      cfg.dbType,
+		columns:     append([]string{}, src.columns...),
+		columnTypes: make([]*mockColumnType, 0, len(src.colTypes)),
+	}
+	for _, ct := range src.colTypes {
+		copied := copyColumnType(ct, cfg.dbType)
+		copied.noDialect = copied.noDialect || !cfg.hasDbType
+		set.columnTypes = append(set.columnTypes, copied)
+	}
+	set.reindex()
+
+	var size int64
+	for {
+		row, err := src.next()
+		if err != nil {
+			return nil, errors.Join(err, rs.Close())
+		}
+		if row == nil {
+			break
+		}
+
+		if cfg.maxRows > 0 && len(set.values) >= cfg.maxRows {
+			return nil, errors.Join(fmt.Errorf("%w: more than %d rows", ErrMaterializeLimit, cfg.maxRows), rs.Close())
+		}
+		for _, v := range row {
+			size += estimateSize(v)
+		}
+		if cfg.maxBytes > 0 && size > cfg.maxBytes {
+			return nil, errors.Join(fmt.Errorf("%w: more than %d bytes after %d rows", ErrMaterializeLimit, cfg.maxBytes, len(set.values)), rs.Close())
+		}
+
+		// hold nullable values as pointers, as a hand-built fixture does
+		for i, ct := range set.columnTypes {
+			if row[i] != nil && ct.colType != nil && ct.colType.Kind() == reflect.Pointer {
+				ptr := reflect.New(ct.colType.Elem())
+				if rv := reflect.ValueOf(row[i]); rv.Type().AssignableTo(ptr.Elem().Type()) {
+					ptr.Elem().Set(rv)
+					row[i] = ptr.Interface()
+				}
+			}
+		}
+		set.values = append(set.values, row)
+	}
+
+	if err = rs.Close(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// copyColumnType captures the metadata of any column type as a mock column type.
+// Metadata from a driver is kept as the driver reported it, rather than run
+// through the mock's own driver rules again.
+func copyColumnType(ct ColumnType, dbType DatabaseType) *mockColumnType {
+	if mct, ok := ct.(*mockColumnType); ok {
+		copied := *mct
+		return &copied
+	}
+
+	var meta columnMetadata
+	meta.nullable, meta.hasNullable = ct.Nullable()
+	meta.length, meta.hasLength = ct.Length()
+	meta.precision, meta.scale, meta.hasDecimalSize = ct.DecimalSize()
+	nullable, length, precision, scale := meta.nullable, meta.length, meta.precision, meta.scale
+	return &mockColumnType{
+		colName:      ct.Name(),
+		colType:      ct.ScanType(),
+		nullable:     nullable,
+		length:       length,
+		precision:    precision,
+		scale:        scale,
+		databaseType: ct.DatabaseTypeName(),
+		dbType:       dbType,
+		copiedMeta:   &meta,
+	}
+}
+
+// estimateSize approximates the memory held by a value, including its interface header
+func estimateSize(v any) int64 {
+	const header = 16
+	switch tv := v.(type) {
+	case nil:
+		return header
+	case string:
+		return header + 16 + int64(len(tv))
+	case []byte:
+		return header + 24 + int64(len(tv))
+	case time.Time:
+		return header + 24
+	case uuid.UUID:
+		return header + 16
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		size := int64(header + 24)
+		for i := range rv.Len() {
+			size += estimateSize(rv.Index(i).Interface())
+		}
+		return size
+	case reflect.Map:
+		size := int64(header + 48)
+		iter := rv.MapRange()
+		for iter.Next() {
+			size += estimateSize(iter.Key().Interface()) + estimateSize(iter.Value().Interface())
+		}
+		return size
+	case reflect.String:
+		return header + 16 + int64(rv.Len())
+	}
+	return header + int64(rv.Type().Size())
+}
@@ -0,0 +1,122 @@
+package sqlrows
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterialize(t *testing.T) {
+	it := newTestCommon(t)
+	src := newOrdersFixture(it)
+
+	mrs, err := Materialize(Filter(src, func(row map[string]any) bool { return row["TENANT"] == "acme" }))
+	require.NoError(t, err)
+	it.rs = mrs
+
+	it.VerifiesColumns([]string{"ID", "TENANT", "AMOUNT", "SHIPPED"}).
+		VerifiesColumnTypes([]testColumnType{
+			{"ID", reflect.TypeOf(int64(0)), "BIGINT", false, 0, 0, 0},
+			{"TENANT", reflect.TypeOf(""), "VARCHAR", false, 16777216, 0, 0},
+			{"AMOUNT", reflect.TypeOf(0.0), "DOUBLE", false, 0, 0, 0},
+			{"SHIPPED", reflect.TypeOf(&it.now), "TIMESTAMP_NTZ", true, 0, 0, 0},
+		})
+
+	// the materialized set can be read more than once
+	for range 2 {
+		mrs.Rewind()
+		it.VerifiesRows(mrs, []string{"ID", "TENANT", "AMOUNT", "SHIPPED"},
+			[]any{int64(1), "acme", 10.5, it.now},
+			[]any{int64(3), "acme", 7.25, nil},
+			[]any{int64(4), "acme", 10.5, it.now},
+		)
+	}
+
+	// and it remains a mock that can be extended
+	mrs.Rewind()
+	mrs.AddColumn("name=NOTE;type=*string", nil)
+	it.VerifiesColumns([]string{"ID", "TENANT", "AMOUNT", "SHIPPED", "NOTE"})
+}
+
+type closeTracker struct {
+	RowSet
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestMaterializeLimits(t *testing.T) {
+	it := newTestCommon(t)
+	src := &closeTracker{RowSet: newOrdersFixture(it)}
+	_, err := Materialize(src, MaxRows(3))
+	assert.True(t, errors.Is(err, ErrMaterializeLimit))
+	assert.EqualError(t, err, "materialize limit exceeded: more than 3 rows")
+	assert.True(t, src.closed)
+
+	big := NewMockRowSet([]string{"name=BODY;type=string"}, DbTypePostgresSQL)
+	for range 10 {
+		big.AddRow([]any{strings.Repeat("x", 1000)})
+	}
+	_, err = Materialize(big, MaxBytes(4096))
+	assert.ErrorIs(t, err, ErrMaterializeLimit)
+	assert.Contains(t, err.Error(), "more than 4096 bytes after 3 rows")
+
+	big.Rewind()
+	mrs, err := Materialize(big, MaxBytes(1<<20), MaxRows(10))
+	require.NoError(t, err)
+	it.VerifiesColumns([]string{"ID", "TENANT", "AMOUNT", "SHIPPED"})
+	cols, _ := mrs.Columns()
+	assert.Equal(t, []string{"BODY"}, cols)
+}
+
+func TestMaterializeSourceError(t *testing.T) {
+	it := newTestCommon(t)
+	src := &errRowSet{RowSet: newOrdersFixture(it)}
+	_, err := Materialize(src)
+	assert.EqualError(t, err, "connection reset")
+	assert.True(t, src.closed)
+}
+
+func TestMaterializeDriverRows(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=NAME;type=string;dbType=VARCHAR", "name=QTY;type=int64"}, DbTypePostgresSQL).
+		AddsRows([][]any{{"a", int64(1)}, {"a", int64(2)}})
+
+	nullable, precision := true, int64(12)
+	driverRows := func() RowSet {
+		return &schemaRowSet{RowSet: it.rs, colTypes: []ColumnType{
+			&driverColumnType{name: "NAME", dbType: "VARCHAR", scanType: reflect.TypeOf(""), nullable: &nullable},
+			&driverColumnType{name: "QTY", dbType: "INT8", scanType: reflect.TypeOf(int64(0)), precision: &precision, scale: 3},
+		}}
+	}
+
+	mrs, err := Materialize(driverRows())
+	require.NoError(t, err)
+	colTypes, err := mrs.ColumnTypes()
+	require.NoError(t, err)
+
+	// the driver's metadata is kept as reported
+	length, ok := colTypes[0].Length()
+	assert.Equal(t, []any{int64(0), false}, []any{length, ok})
+	isNullable, ok := colTypes[0].Nullable()
+	assert.Equal(t, []any{true, true}, []any{isNullable, ok})
+	p, scale, ok := colTypes[1].DecimalSize()
+	assert.Equal(t, []any{int64(12), int64(3), true}, []any{p, scale, ok})
+
+	// nothing says which database the rows came from
+	rs := GroupBy(mrs, "NAME").Agg(Sum("QTY"))
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "database type of row set is unknown; call Dialect")
+
+	it.rs.Rewind()
+	mrs, err = Materialize(driverRows(), MaterializeDialect(DbTypePostgresSQL))
+	require.NoError(t, err)
+	it.VerifiesRows(GroupBy(mrs, "NAME").Agg(Sum("QTY")), []string{"NAME", "SUM(QTY)"}, []any{"a", int64(3)})
+}
@@ -0,0 +1,357 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// derivedRowSet is a RowSet whose rows are produced by a pull function,
+	// typically reading from one or more source row sets
+	derivedRowSet struct {
+		columns  []string
+		colTypes []ColumnType
+		pull     func() ([]any, error)
+		closer   func() error
+		current  []any
+		started  bool
+		done     bool
+		closed   bool
+		err      error
+	}
+
+	// sourceRows reads a RowSet along with the column metadata needed to address its values
+	sourceRows struct {
+		rs       RowSet
+		columns  []string
+		colTypes []ColumnType
+		lookup   map[string]int
+	}
+)
+
+// Returns the rows of [rs] for which [pred] returns true. The predicate receives
+// the row keyed by column name; nullable values are dereferenced, so NULL is nil.
+// Rows are streamed from [rs], which is closed when the result is closed.
+func Filter(rs RowSet, pred func(row map[string]any) bool) RowSet {
+	src, err := openSource(rs)
+	if err != nil {
+		return failedRowSet(err, rs)
+	}
+
+	return src.derive(src.columns, src.colTypes, func() ([]any, error) {
+		for {
+			row, err := src.next()
+			if row == nil || err != nil {
+				return nil, err
+			}
+			if pred(src.rowMap(row)) {
+				return row, nil
+			}
+		}
+	})
+}
+
+// Returns only the named columns of [rs], in the order given. Column names are
+// matched case-insensitively. Rows are streamed from [rs].
+func Project(rs RowSet, cols ...string) RowSet {
+	src, err := openSource(rs)
+	if err != nil {
+		return failedRowSet(err, rs)
+	}
+
+	indexes := make([]int, 0, len(cols))
+	columns := make([]string, 0, len(cols))
+	colTypes := make([]ColumnType, 0, len(cols))
+	for _, col := range cols {
+		index, err := src.index(col)
+		if err != nil {
+			return failedRowSet(err, rs)
+		}
+		indexes = append(indexes, index)
+		columns = append(columns, src.columns[index])
+		colTypes = append(colTypes, src.colTypes[index])
+	}
+
+	return src.derive(columns, colTypes, func() ([]any, error) {
+		row, err := src.next()
+		if row == nil || err != nil {
+			return nil, err
+		}
+		projected := make([]any, len(indexes))
+		for i, index := range indexes {
+			projected[i] = row[index]
+		}
+		return projected, nil
+	})
+}
+
+// Returns the rows of [rs] ordered by column [col]. The sort is stable, and NULLs
+// sort last when ascending and first when descending, as in Snowflake and Postgres.
+// All rows are read from [rs] on the first call to Next.
+func SortBy(rs RowSet, col string, desc bool) RowSet {
+	src, err := openSource(rs)
+	if err != nil {
+		return failedRowSet(err, rs)
+	}
+	index, err := src.index(col)
+	if err != nil {
+		return failedRowSet(err, rs)
+	}
+
+	var rows [][]any
+	loaded := false
+	return src.derive(src.columns, src.colTypes, func() ([]any, error) {
+		if !loaded {
+			loaded = true
+			if rows, err = src.readAll(); err != nil {
+				return nil, err
+			}
+			slices.SortStableFunc(rows, func(a, b []any) int {
+				order := compareNullable(a[index], b[index])
+				if desc {
+					return -order
+				}
+				return order
+			})
+		}
+
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	})
+}
+
+// Returns at most [n] rows of [rs]. Rows are streamed from [rs].
+func Limit(rs RowSet, n int) RowSet {
+	src, err := openSource(rs)
+	if err != nil {
+		return failedRowSet(err, rs)
+	}
+
+	count := 0
+	return src.derive(src.columns, src.colTypes, func() ([]any, error) {
+		if count >= n {
+			return nil, nil
+		}
+		count++
+		return src.next()
+	})
+}
+
+// Returns the rows of [rs] after skipping the first [n]. Rows are streamed from [rs].
+func Offset(rs RowSet, n int) RowSet {
+	src, err := openSource(rs)
+	if err != nil {
+		return failedRowSet(err, rs)
+	}
+
+	skipped := 0
+	return src.derive(src.columns, src.colTypes, func() ([]any, error) {
+		for skipped < n {
+			row, err := src.next()
+			if row == nil || err != nil {
+				return nil, err
+			}
+			skipped++
+		}
+		return src.next()
+	})
+}
+
+// Returns the rows of [rs] with duplicates removed, keeping the first occurrence.
+// Rows are streamed from [rs]; only a key per distinct row is retained.
+func Distinct(rs RowSet) RowSet {
+	src, err := openSource(rs)
+	if err != nil {
+		return failedRowSet(err, rs)
+	}
+
+	seen := map[string]struct{}{}
+	return src.derive(src.columns, src.colTypes, func() ([]any, error) {
+		for {
+			row, err := src.next()
+			if row == nil || err != nil {
+				return nil, err
+			}
+			key := rowKey(row)
+			if _, dup := seen[key]; !dup {
+				seen[key] = struct{}{}
+				return row, nil
+			}
+		}
+	})
+}
+
+// compareNullable orders values with NULL greater than any other value
+func compareNullable(a, b any) int {
+	a = normalizeValue(a)
+	b = normalizeValue(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return compareValues(a, b)
+}
+
+func openSource(rs RowSet) (*sourceRows, error) {
+	columns, err := rs.Columns()
+	if err != nil {
+		return nil, err
+	}
+	colTypes, err := rs.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(colTypes) != len(columns) {
+		return nil, fmt.Errorf("row set has %d columns but %d column types", len(columns), len(colTypes))
+	}
+
+	lookup := make(map[string]int, len(columns))
+	for i, col := range columns {
+		lookup[strings.ToLower(col)] = i
+	}
+
+	return &sourceRows{
+		rs:       rs,
+		columns:  columns,
+		colTypes: colTypes,
+		lookup:   lookup,
+	}, nil
+}
+
+// next reads the next row, returning nil at the end of the row set
+func (src *sourceRows) next() ([]any, error) {
+	if !src.rs.Next() {
+		return nil, src.rs.Err()
+	}
+	return readRow(src.rs, len(src.columns))
+}
+
+func (src *sourceRows) readAll() ([][]any, error) {
+	var rows [][]any
+	for {
+		row, err := src.next()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			return rows, nil
+		}
+		rows = append(rows, row)
+	}
+}
+
+func (src *sourceRows) index(col string) (int, error) {
+	index, valid := src.lookup[strings.ToLower(col)]
+	if !valid {
+		return 0, fmt.Errorf("column %s does not exist", col)
+	}
+	return index, nil
+}
+
+func (src *sourceRows) rowMap(row []any) map[string]any {
+	m := make(map[string]any, len(row))
+	for i, col := range src.columns {
+		m[col] = row[i]
+	}
+	return m
+}
+
+func (src *sourceRows) derive(columns []string, colTypes []ColumnType, pull func() ([]any, error)) *derivedRowSet {
+	return &derivedRowSet{
+		columns:  columns,
+		colTypes: colTypes,
+		pull:     pull,
+		closer:   src.rs.Close,
+	}
+}
+
+// failedRowSet returns an empty row set reporting [err], which closes [sources] when closed
+func failedRowSet(err error, sources ...RowSet) *derivedRowSet {
+	return &derivedRowSet{
+		done:   true,
+		err:    err,
+		closer: closeAll(sources),
+	}
+}
+
+func closeAll(sources []RowSet) func() error {
+	return func() error {
+		var errs []error
+		for _, rs := range sources {
+			errs = append(errs, rs.Close())
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func (d *derivedRowSet) Close() error {
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	d.done = true
+	d.current = nil
+	if d.closer == nil {
+		return nil
+	}
+	return d.closer()
+}
+
+func (d *derivedRowSet) ColumnTypes() ([]ColumnType, error) {
+	if d.colTypes == nil && d.err != nil {
+		return nil, d.err
+	}
+	return d.colTypes, nil
+}
+
+func (d *derivedRowSet) Columns() ([]string, error) {
+	if d.columns == nil && d.err != nil {
+		return nil, d.err
+	}
+	return d.columns, nil
+}
+
+func (d *derivedRowSet) Err() error {
+	return d.err
+}
+
+func (d *derivedRowSet) Next() bool {
+	d.started = true
+	d.current = nil
+	if d.done {
+		return false
+	}
+
+	row, err := d.pull()
+	if err != nil || row == nil {
+		d.err = err
+		d.done = true
+		return false
+	}
+	d.current = row
+	return true
+}
+
+func (d *derivedRowSet) NextResultSet() bool {
+	return false
+}
+
+func (d *derivedRowSet) Scan(dest ...any) error {
+	if !d.started {
+		return errors.New("sql: Scan called without calling Next")
+	}
+	if d.current == nil {
+		return fmt.Errorf("no more rows")
+	}
+	return assignRow(dest, d.current, d.columns)
+}
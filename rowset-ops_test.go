@@ -0,0 +1,158 @@
+package sqlrows
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrdersFixture(it *testCommon) MockRowSet {
+	it.HasMockRowSet([]string{
+		"name=ID;type=int64",
+		"name=TENANT;type=string",
+		"name=AMOUNT;type=float64",
+		"name=SHIPPED;type=*time.Time",
+	}, DbTypeSnowflake).
+		AddsRows([][]any{
+			{int64(1), "acme", 10.5, &it.now},
+			{int64(2), "globex", 3.0, nil},
+			{int64(3), "acme", 7.25, nil},
+			{int64(4), "acme", 10.5, &it.now},
+		})
+	return it.rs
+}
+
+func TestFilter(t *testing.T) {
+	it := newTestCommon(t)
+	rs := Filter(newOrdersFixture(it), func(row map[string]any) bool {
+		return row["TENANT"] == "acme" && row["SHIPPED"] == nil
+	})
+
+	it.VerifiesRows(rs, []string{"ID", "TENANT", "AMOUNT", "SHIPPED"},
+		[]any{int64(3), "acme", 7.25, nil},
+	)
+}
+
+func TestProject(t *testing.T) {
+	it := newTestCommon(t)
+	rs := Project(newOrdersFixture(it), "amount", "ID")
+
+	colTypes, err := rs.ColumnTypes()
+	require.NoError(t, err)
+	require.Len(t, colTypes, 2)
+	assert.Equal(t, "DOUBLE", colTypes[0].DatabaseTypeName())
+	assert.Equal(t, "BIGINT", colTypes[1].DatabaseTypeName())
+
+	it.VerifiesRows(rs, []string{"AMOUNT", "ID"},
+		[]any{10.5, int64(1)},
+		[]any{3.0, int64(2)},
+		[]any{7.25, int64(3)},
+		[]any{10.5, int64(4)},
+	)
+}
+
+func TestProjectUnknownColumn(t *testing.T) {
+	it := newTestCommon(t)
+	rs := Project(newOrdersFixture(it), "ID", "XYZ")
+
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "column XYZ does not exist")
+	_, err := rs.Columns()
+	assert.Error(t, err)
+	assert.NoError(t, rs.Close())
+}
+
+func TestSortBy(t *testing.T) {
+	it := newTestCommon(t)
+	rs := Project(SortBy(newOrdersFixture(it), "amount", false), "ID")
+	it.VerifiesRows(rs, []string{"ID"}, []any{int64(2)}, []any{int64(3)}, []any{int64(1)}, []any{int64(4)})
+
+	rs = Project(SortBy(newOrdersFixture(it), "shipped", true), "ID")
+	it.VerifiesRows(rs, []string{"ID"}, []any{int64(2)}, []any{int64(3)}, []any{int64(1)}, []any{int64(4)})
+
+	rs = Project(SortBy(newOrdersFixture(it), "shipped", false), "ID")
+	it.VerifiesRows(rs, []string{"ID"}, []any{int64(1)}, []any{int64(4)}, []any{int64(2)}, []any{int64(3)})
+}
+
+func TestLimitOffset(t *testing.T) {
+	it := newTestCommon(t)
+	rs := Project(Limit(Offset(newOrdersFixture(it), 1), 2), "ID")
+	it.VerifiesRows(rs, []string{"ID"}, []any{int64(2)}, []any{int64(3)})
+
+	rs = Project(Offset(newOrdersFixture(it), 10), "ID")
+	it.VerifiesRows(rs, []string{"ID"})
+
+	rs = Limit(newOrdersFixture(it), 0)
+	it.VerifiesRows(rs, []string{"ID", "TENANT", "AMOUNT", "SHIPPED"})
+}
+
+func TestDistinct(t *testing.T) {
+	it := newTestCommon(t)
+	rs := Distinct(Project(newOrdersFixture(it), "TENANT", "AMOUNT", "SHIPPED"))
+
+	it.VerifiesRows(rs, []string{"TENANT", "AMOUNT", "SHIPPED"},
+		[]any{"acme", 10.5, it.now},
+		[]any{"globex", 3.0, nil},
+		[]any{"acme", 7.25, nil},
+	)
+}
+
+func TestDistinctLargeIntegers(t *testing.T) {
+	it := newTestCommon(t).
+		HasMockRowSet([]string{"name=ID;type=int64", "name=AMOUNT;type=any"}, DbTypePostgresSQL).
+		AddsRows([][]any{
+			{int64(9007199254740992), int64(1)},
+			{int64(9007199254740993), 1.0},
+			{int64(9007199254740993), uint8(1)},
+		})
+
+	it.VerifiesRows(Distinct(it.rs), []string{"ID", "AMOUNT"},
+		[]any{int64(9007199254740992), int64(1)},
+		[]any{int64(9007199254740993), 1.0},
+	)
+}
+
+func TestDerivedRowSetScan(t *testing.T) {
+	it := newTestCommon(t)
+	rs := Filter(newOrdersFixture(it), func(row map[string]any) bool { return row["ID"] == int64(1) })
+
+	var id int
+	var tenant string
+	var amount float64
+	var shipped *time.Time
+	assert.EqualError(t, rs.Scan(&id, &tenant, &amount, &shipped), "sql: Scan called without calling Next")
+
+	require.True(t, rs.Next())
+	require.NoError(t, rs.Scan(&id, &tenant, &amount, &shipped))
+	assert.Equal(t, 1, id)
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, 10.5, amount)
+	require.NotNil(t, shipped)
+	assert.Equal(t, it.now, *shipped)
+
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Scan(&id, &tenant, &amount, &shipped), "no more rows")
+}
+
+type errRowSet struct {
+	RowSet
+	closed bool
+}
+
+func (e *errRowSet) Next() bool   { return false }
+func (e *errRowSet) Err() error   { return errors.New("connection reset") }
+func (e *errRowSet) Close() error { e.closed = true; return nil }
+
+func TestDerivedRowSetPropagatesErrors(t *testing.T) {
+	it := newTestCommon(t)
+	src := &errRowSet{RowSet: newOrdersFixture(it)}
+	rs := SortBy(Filter(src, func(map[string]any) bool { return true }), "ID", false)
+
+	assert.False(t, rs.Next())
+	assert.EqualError(t, rs.Err(), "connection reset")
+	assert.NoError(t, rs.Close())
+	assert.True(t, src.closed)
+}
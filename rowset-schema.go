@@ -0,0 +1,189 @@
+package sqlrows
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// expectedColumn is a parsed ExpectSchema spec; nil fields are not checked
+	expectedColumn struct {
+		name      string
+		scanType  reflect.Type
+		nullable  *bool
+		dbType    *string
+		length    *int64
+		precision *int64
+		scale     *int64
+	}
+)
+
+// Checks the columns of [rs] against column specs in the NewMockRowSet syntax,
+// such as "name=AMOUNT;type=*float64;dbType=NUMBER;precision=38;scale=2". Only the
+// keys given in a spec are checked; a pointer type also expects a nullable column.
+// The columns must appear in spec order. Every mismatch is reported in one test
+// failure. Only metadata is read, so the rows of [rs] are left for the caller.
+func ExpectSchema(t TestingT, rs RowSet, spec ...string) bool {
+	t.Helper()
+
+	problems, err := schemaProblems(rs, spec)
+	if err != nil {
+		t.Errorf("checking schema: %v", err)
+		return false
+	}
+	if len(problems) > 0 {
+		t.Errorf("schema does not match:\n  %s", strings.Join(problems, "\n  "))
+		return false
+	}
+	return true
+}
+
+func schemaProblems(rs RowSet, spec []string) ([]string, error) {
+	expected := make([]expectedColumn, 0, len(spec))
+	for _, colSpec := range spec {
+		ec, err := parseExpectedColumn(colSpec)
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, ec)
+	}
+
+	columns, err := rs.Columns()
+	if err != nil {
+		return nil, err
+	}
+	colTypes, err := rs.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	// drivers differ in how they case unquoted identifiers, so names match without regard to case
+	index := make(map[string]int, len(columns))
+	for i, col := range columns {
+		index[strings.ToLower(col)] = i
+	}
+
+	var problems []string
+	var names []string
+	var positions []int
+	matched := map[int]struct{}{}
+	for _, ec := range expected {
+		i, found := index[strings.ToLower(ec.name)]
+		if !found {
+			problems = append(problems, fmt.Sprintf("missing column %s", ec.name))
+			continue
+		}
+		matched[i] = struct{}{}
+		names = append(names, ec.name)
+		positions = append(positions, i)
+		problems = append(problems, ec.check(colTypes[i])...)
+	}
+	for i, col := range columns {
+		if _, found := matched[i]; !found {
+			problems = append(problems, fmt.Sprintf("unexpected column %s", col))
+		}
+	}
+
+	if !slices.IsSorted(positions) {
+		order := make([]string, len(positions))
+		for i, j := range slices.Sorted(slices.Values(positions)) {
+			order[i] = columns[j]
+		}
+		problems = append(problems, fmt.Sprintf("columns are in order %s, expected %s", strings.Join(order, ", "), strings.Join(names, ", ")))
+	}
+	return problems, nil
+}
+
+// parseExpectedColumn reads a column spec with the NewMockRowSet grammar,
+// keeping only the keys that were given
+func parseExpectedColumn(colSpec string) (expectedColumn, error) {
+	spec, err := splitColumnSpec(colSpec)
+	if err != nil {
+		return expectedColumn{}, err
+	}
+
+	ec := expectedColumn{
+		name:      spec.name,
+		dbType:    spec.dbType,
+		length:    spec.length,
+		precision: spec.precision,
+		scale:     spec.scale,
+	}
+	if spec.typeName != "" {
+		isPointer := strings.HasPrefix(spec.typeName, "*")
+		base := baseTypes[strings.TrimPrefix(spec.typeName, "*")]
+		if base == nil {
+			return ec, fmt.Errorf("unsupported type: %s", spec.typeName)
+		}
+		ec.scanType = base
+		if isPointer {
+			ec.scanType = reflect.PointerTo(base)
+		}
+		ec.nullable = &isPointer
+	}
+	return ec, nil
+}
+
+// check compares the given keys of the spec with a column type
+func (ec *expectedColumn) check(ct ColumnType) []string {
+	var problems []string
+	name := ec.name
+
+	if ec.scanType != nil && !scanTypeMatches(ec.scanType, ct.ScanType()) {
+		problems = append(problems, fmt.Sprintf("column %s scan type is %v, expected %v", name, ct.ScanType(), ec.scanType))
+	}
+	if ec.nullable != nil {
+		if nullable, ok := ct.Nullable(); ok && nullable != *ec.nullable {
+			problems = append(problems, fmt.Sprintf("column %s nullable is %v, expected %v", name, nullable, *ec.nullable))
+		}
+	}
+	if ec.dbType != nil && !strings.EqualFold(ct.DatabaseTypeName(), *ec.dbType) {
+		problems = append(problems, fmt.Sprintf("column %s database type is %s, expected %s", name, ct.DatabaseTypeName(), *ec.dbType))
+	}
+	if ec.length != nil {
+		if length, ok := ct.Length(); !ok || length != *ec.length {
+			problems = append(problems, fmt.Sprintf("column %s length is %s, expected %d", name, optionalInt(length, ok), *ec.length))
+		}
+	}
+	if ec.precision != nil || ec.scale != nil {
+		precision, scale, ok := ct.DecimalSize()
+		if ec.precision != nil && (!ok || precision != *ec.precision) {
+			problems = append(problems, fmt.Sprintf("column %s precision is %s, expected %d", name, optionalInt(precision, ok), *ec.precision))
+		}
+		if ec.scale != nil && (!ok || scale != *ec.scale) {
+			problems = append(problems, fmt.Sprintf("column %s scale is %s, expected %d", name, optionalInt(scale, ok), *ec.scale))
+		}
+	}
+	return problems
+}
+
+// scanTypeMatches accepts the spec's type, and for a pointer spec also the
+// sql.NullXxx style struct that drivers report for nullable columns
+func scanTypeMatches(expected, actual reflect.Type) bool {
+	if expected == actual {
+		return true
+	}
+	if expected.Kind() != reflect.Pointer || actual == nil || actual.Kind() != reflect.Struct || actual.NumField() != 2 {
+		return false
+	}
+	valid, ok := actual.FieldByName("Valid")
+	if !ok || valid.Type.Kind() != reflect.Bool {
+		return false
+	}
+	for i := range actual.NumField() {
+		if actual.Field(i).Name != "Valid" && actual.Field(i).Type == expected.Elem() {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalInt(n int64, ok bool) string {
+	if !ok {
+		return "not reported"
+	}
+	return strconv.FormatInt(n, 10)
+}
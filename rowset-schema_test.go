@@ -0,0 +1,99 @@
+package sqlrows
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLedgerFixture(t *testing.T) MockRowSet {
+	return newTestCommon(t).
+		HasMockRowSet([]string{
+			"name=ID;type=int64",
+			"name=AMOUNT;type=*float64;dbType=NUMBER;precision=38;scale=2",
+			"name=MEMO;type=*string;length=200",
+		}, DbTypeSnowflake).rs
+}
+
+func TestExpectSchema(t *testing.T) {
+	rs := newLedgerFixture(t)
+	assert.True(t, ExpectSchema(t, rs,
+		"name=ID;type=int64;dbType=BIGINT",
+		"name=AMOUNT;type=*float64;dbType=number;precision=38;scale=2",
+		"name=MEMO;length=200",
+	))
+
+	// the cursor is left alone
+	rs.AddRow([]any{int64(1), nil, nil})
+	assert.True(t, rs.Next())
+}
+
+func TestExpectSchemaCaseInsensitiveNames(t *testing.T) {
+	// Postgres folds unquoted identifiers to lower case, Snowflake to upper case
+	assert.True(t, ExpectSchema(t, newLedgerFixture(t),
+		"name=id;type=int64",
+		"name=Amount;type=*float64",
+		"name=memo",
+	))
+}
+
+func TestExpectSchemaReportsEveryMismatch(t *testing.T) {
+	ft := &fakeT{}
+	assert.False(t, ExpectSchema(ft, newLedgerFixture(t),
+		"name=AMOUNT;type=float64;dbType=FLOAT;precision=38;scale=0",
+		"name=ID;type=int64",
+		"name=CREATED;type=time.Time",
+	))
+	require.Len(t, ft.messages, 1)
+	assert.Equal(t, ""+
+		"schema does not match:\n"+
+		"  column AMOUNT scan type is *float64, expected float64\n"+
+		"  column AMOUNT nullable is true, expected false\n"+
+		"  column AMOUNT database type is NUMBER, expected FLOAT\n"+
+		"  column AMOUNT scale is 2, expected 0\n"+
+		"  missing column CREATED\n"+
+		"  unexpected column MEMO\n"+
+		"  columns are in order ID, AMOUNT, expected AMOUNT, ID",
+		ft.messages[0])
+}
+
+func TestExpectSchemaUnreportedMetadata(t *testing.T) {
+	ft := &fakeT{}
+	assert.False(t, ExpectSchema(ft, newLedgerFixture(t),
+		"name=ID;length=10;precision=18",
+		"name=AMOUNT",
+		"name=MEMO",
+	))
+	require.Len(t, ft.messages, 1)
+	assert.Equal(t, ""+
+		"schema does not match:\n"+
+		"  column ID length is not reported, expected 10\n"+
+		"  column ID precision is 38, expected 18",
+		ft.messages[0])
+}
+
+func TestExpectSchemaInvalidSpec(t *testing.T) {
+	for spec, msg := range map[string]string{
+		"type=int64":          "column spec missing required 'name': type=int64",
+		"name=ID;type=int128": "unsupported type: int128",
+		"name=ID;scale=x":     "invalid scale in column spec: x",
+		"name=ID;color=red":   "unknown keyword in column spec: color",
+		"name":                "invalid key=value pair in column spec: name",
+	} {
+		ft := &fakeT{}
+		assert.False(t, ExpectSchema(ft, newLedgerFixture(t), spec), spec)
+		require.Len(t, ft.messages, 1, spec)
+		assert.Equal(t, "checking schema: "+msg, ft.messages[0], spec)
+	}
+}
+
+func TestScanTypeMatchesDriverNullTypes(t *testing.T) {
+	ptrInt64 := reflect.PointerTo(baseTypes["int64"])
+	assert.True(t, scanTypeMatches(ptrInt64, reflect.TypeOf(sql.NullInt64{})))
+	assert.True(t, scanTypeMatches(reflect.PointerTo(baseTypes["string"]), reflect.TypeOf(sql.Null[string]{})))
+	assert.False(t, scanTypeMatches(ptrInt64, reflect.TypeOf(sql.NullString{})))
+	assert.False(t, scanTypeMatches(baseTypes["int64"], reflect.TypeOf(sql.NullInt64{})))
+}
@@ -0,0 +1,159 @@
+package sqlrows
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"reflect"
+	"strings"
+)
+
+var dbTypeConstants = map[DatabaseType]string{
+	DbTypeSnowflake:   "DbTypeSnowflake",
+	DbTypePostgresSQL: "DbTypePostgresSQL",
+	DbTypeMsSQL:       "DbTypeMsSQL",
+}
+
+// Returns NewMockRowSet column specs that reproduce the columns of [rs], which is
+// typically a row set from a development database. The database type name,
+// nullability, length, precision and scale come from the column types; keys that
+// match what the [dbType] driver reports by default are left out. Only metadata
+// is read, so the rows of [rs] are left for the caller.
+func ColumnSpecs(rs RowSet, dbType DatabaseType) ([]string, error) {
+	defaultTable := dbTypeDefaults[dbType]
+	if defaultTable == nil {
+		return nil, errors.New("invalid database type")
+	}
+
+	columns, err := rs.Columns()
+	if err != nil {
+		return nil, err
+	}
+	colTypes, err := rs.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make([]string, 0, len(columns))
+	var errs []error
+	for i, col := range columns {
+		ct := colTypes[i]
+		typeName, err := mockTypeName(ct)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("column %s: %w", col, err))
+			continue
+		}
+
+		// a mock column with the default sizes tells which keys are needed
+		dbColType := ct.DatabaseTypeName()
+		defaults := defaultTable[dbColType]
+		probe := &mockColumnType{
+			length:       defaults.length,
+			precision:    defaults.precision,
+			scale:        defaults.scale,
+			databaseType: dbColType,
+			dbType:       dbType,
+		}
+		defLength, _ := probe.Length()
+		defPrecision, defScale, _ := probe.DecimalSize()
+
+		parts := []string{"name=" + col, "type=" + typeName}
+		if dbColType != "" && dbColType != typeDbName(typeName, dbType) {
+			parts = append(parts, "dbType="+dbColType)
+		}
+		if length, ok := ct.Length(); ok && length != defLength {
+			parts = append(parts, fmt.Sprintf("length=%d", length))
+		}
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			if precision != defPrecision {
+				parts = append(parts, fmt.Sprintf("precision=%d", precision))
+			}
+			if scale != defScale {
+				parts = append(parts, fmt.Sprintf("scale=%d", scale))
+			}
+		}
+		specs = append(specs, strings.Join(parts, ";"))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return specs, nil
+}
+
+// Returns gofmt'd Go source for a fixture constructor named [funcName], which
+// builds a MockRowSet with the columns of [rs] and adds the rows passed to it.
+func FixtureSource(rs RowSet, dbType DatabaseType, funcName string) (string, error) {
+	specs, err := ColumnSpecs(rs, dbType)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "func %s(rows ...[]any) sqlrows.MockRowSet {\n", funcName)
+	buf.WriteString("rs := sqlrows.NewMockRowSet([]string{\n")
+	for _, spec := range specs {
+		fmt.Fprintf(&buf, "%q,\n", spec)
+	}
+	fmt.Fprintf(&buf, "}, sqlrows.%s)\n", dbTypeConstants[dbType])
+	buf.WriteString("for _, row := range rows {\nrs.AddRow(row)\n}\nreturn rs\n}\n")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("formatting fixture source: %w", err)
+	}
+	return string(source), nil
+}
+
+// mockTypeName maps a column's scan type back to a baseTypes name, with a
+// pointer for nullable columns
+func mockTypeName(ct ColumnType) (string, error) {
+	scanType := ct.ScanType()
+	if scanType == nil {
+		return "", errors.New("scan type is unknown")
+	}
+
+	base := scanType
+	pointer := false
+	switch {
+	case scanType.Kind() == reflect.Pointer:
+		base = scanType.Elem()
+		pointer = true
+	case scanType.Kind() == reflect.Struct:
+		// sql.NullXxx and sql.Null[T] wrap the value with a Valid flag
+		if _, ok := scanType.FieldByName("Valid"); ok && scanType.NumField() == 2 {
+			for i := range scanType.NumField() {
+				if field := scanType.Field(i); field.Name != "Valid" {
+					base = field.Type
+					pointer = true
+				}
+			}
+		}
+	}
+
+	name, found := baseTypeName(base)
+	if !found {
+		return "", fmt.Errorf("no mock type for scan type %v", scanType)
+	}
+	if nullable, ok := ct.Nullable(); ok {
+		pointer = nullable
+	}
+	if pointer {
+		return "*" + name, nil
+	}
+	return name, nil
+}
+
+// typeDbName is the database type name parseColumnSpec picks for a mock type
+func typeDbName(typeName string, dbType DatabaseType) string {
+	baseType := strings.TrimPrefix(typeName, "*")
+	switch dbType {
+	case DbTypeSnowflake:
+		return dbTypesSnowflake[baseType]
+	case DbTypePostgresSQL:
+		return dbTypesPostgres[baseType]
+	case DbTypeMsSQL:
+		return dbTypesMsSql[baseType]
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package sqlrows
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// driverColumnType reports metadata the way a database driver does
+	driverColumnType struct {
+		name      string
+		dbType    string
+		scanType  reflect.Type
+		nullable  *bool
+		length    *int64
+		precision *int64
+		scale     int64
+	}
+
+	schemaRowSet struct {
+		RowSet
+		colTypes []ColumnType
+	}
+)
+
+func (d *driverColumnType) DatabaseTypeName() string { return d.dbType }
+func (d *driverColumnType) Name() string             { return d.name }
+func (d *driverColumnType) ScanType() reflect.Type   { return d.scanType }
+
+func (d *driverColumnType) DecimalSize() (int64, int64, bool) {
+	if d.precision == nil {
+		return 0, 0, false
+	}
+	return *d.precision, d.scale, true
+}
+
+func (d *driverColumnType) Length() (int64, bool) {
+	if d.length == nil {
+		return 0, false
+	}
+	return *d.length, true
+}
+
+func (d *driverColumnType) Nullable() (bool, bool) {
+	if d.nullable == nil {
+		return false, false
+	}
+	return *d.nullable, true
+}
+
+func (s *schemaRowSet) Columns() ([]string, error) {
+	columns := make([]string, len(s.colTypes))
+	for i, ct := range s.colTypes {
+		columns[i] = ct.Name()
+	}
+	return columns, nil
+}
+
+func (s *schemaRowSet) ColumnTypes() ([]ColumnType, error) {
+	return s.colTypes, nil
+}
+
+func TestColumnSpecsRoundTrip(t *testing.T) {
+	original := NewMockRowSet([]string{
+		"name=ID;type=int64",
+		"name=AMOUNT;type=*float64;dbType=NUMBER;precision=38;scale=2",
+		"name=MEMO;type=*string;length=200",
+		"name=CODE;type=string;dbType=CHAR;length=0",
+		"name=NOTE;type=string;length=0",
+		"name=KEY;type=uuid.UUID",
+	}, DbTypeSnowflake)
+
+	specs, err := ColumnSpecs(original, DbTypeSnowflake)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name=ID;type=int64",
+		"name=AMOUNT;type=*float64;dbType=NUMBER;scale=2",
+		"name=MEMO;type=*string;length=200",
+		"name=CODE;type=string;dbType=CHAR",
+		"name=NOTE;type=string",
+		"name=KEY;type=uuid.UUID",
+	}, specs)
+
+	copied := NewMockRowSet(specs, DbTypeSnowflake)
+	diff, err := Diff(original, copied)
+	require.NoError(t, err)
+	assert.True(t, diff.Equal(), diff.String())
+}
+
+func TestColumnSpecsFromDriverMetadata(t *testing.T) {
+	yes, no := true, false
+	length, precision := int64(64), int64(10)
+	rs := &schemaRowSet{colTypes: []ColumnType{
+		&driverColumnType{name: "id", dbType: "INT8", scanType: reflect.TypeOf(int64(0)), nullable: &no},
+		&driverColumnType{name: "email", dbType: "VARCHAR", scanType: reflect.TypeOf(sql.NullString{}), nullable: &yes, length: &length},
+		&driverColumnType{name: "balance", dbType: "NUMERIC", scanType: reflect.TypeOf(float64(0)), precision: &precision, scale: 2},
+	}}
+
+	specs, err := ColumnSpecs(rs, DbTypePostgresSQL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name=id;type=int64;dbType=INT8",
+		"name=email;type=*string;dbType=VARCHAR;length=64",
+		"name=balance;type=float64;dbType=NUMERIC;precision=10;scale=2",
+	}, specs)
+}
+
+func TestColumnSpecsUnmappedTypes(t *testing.T) {
+	rs := &schemaRowSet{colTypes: []ColumnType{
+		&driverColumnType{name: "payload", dbType: "_INT4", scanType: reflect.TypeOf([]int32(nil))},
+		&driverColumnType{name: "id", dbType: "INT8", scanType: reflect.TypeOf(int64(0))},
+		&driverColumnType{name: "extra", dbType: "JSONB"},
+	}}
+
+	_, err := ColumnSpecs(rs, DbTypePostgresSQL)
+	assert.EqualError(t, err, "column payload: no mock type for scan type []int32\ncolumn extra: scan type is unknown")
+
+	_, err = ColumnSpecs(rs, DatabaseType(99))
+	assert.EqualError(t, err, "invalid database type")
+}
+
+func TestFixtureSource(t *testing.T) {
+	rs := NewMockRowSet([]string{
+		"name=ID;type=int64",
+		"name=NAME;type=*string;length=50",
+	}, DbTypeMsSQL)
+
+	source, err := FixtureSource(rs, DbTypeMsSQL, "newCustomers")
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"func newCustomers(rows ...[]any) sqlrows.MockRowSet {\n"+
+		"\trs := sqlrows.NewMockRowSet([]string{\n"+
+		"\t\t\"name=ID;type=int64\",\n"+
+		"\t\t\"name=NAME;type=*string;length=50\",\n"+
+		"\t}, sqlrows.DbTypeMsSQL)\n"+
+		"\tfor _, row := range rows {\n"+
+		"\t\trs.AddRow(row)\n"+
+		"\t}\n"+
+		"\treturn rs\n"+
+		"}\n",
+		source)
+}
@@ -0,0 +1,203 @@
+package sqlrows
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	TypeOption func(cfg *typeConfig)
+
+	typeConfig struct {
+		decimalType reflect.Type
+	}
+
+	// sqlTypeDecl is a parsed SQL type such as NUMBER(12,2) or TIMESTAMP(3) WITH TIME ZONE
+	sqlTypeDecl struct {
+		name  string   // upper case words of the type, without arguments
+		args  []string // the parenthesized arguments
+		array bool
+	}
+)
+
+// Sets the Go type that fixed-point values with a fractional scale scan as. The
+// default is float64; drivers that keep the exact digits scan as string, as
+// gosnowflake does for NUMBER(p,s) unless it is asked for floats.
+func DecimalScanType(t reflect.Type) TypeOption {
+	return func(cfg *typeConfig) {
+		cfg.decimalType = t
+	}
+}
+
+func newTypeConfig(opts []TypeOption) typeConfig {
+	cfg := typeConfig{decimalType: float64Type}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Returns the Go type the [dbType] driver scans a SQL type as, the inverse of
+// the mock's type tables. [sqlType] may be an alias or parameterized, as in
+// INT4, NUMBER(10,0) or VARCHAR(64). A nullable column gets a pointer type, the
+// same as a mock column spec.
+func GoTypeFor(dbType DatabaseType, sqlType string, nullable bool, opts ...TypeOption) (reflect.Type, error) {
+	if dbTypeDefaults[dbType] == nil {
+		return nil, errors.New("invalid database type")
+	}
+
+	tokens, err := tokenizeDDL(sqlType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SQL type %q: %w", sqlType, err)
+	}
+	decl, pos, err := parseSQLType(tokens, 0)
+	if err == nil && (pos != len(tokens) || decl.name == "") {
+		err = errors.New("unexpected text")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid SQL type %q: %w", sqlType, err)
+	}
+
+	goType, err := decl.goType(dbType, newTypeConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	if nullable {
+		goType = reflect.PointerTo(goType)
+	}
+	return goType, nil
+}
+
+// parseSQLType reads the type words starting at [pos], with arguments possibly
+// between them, and returns the position after the type
+func parseSQLType(tokens []ddlToken, pos int) (decl sqlTypeDecl, end int, err error) {
+typeWords:
+	for pos < len(tokens) {
+		tok := tokens[pos]
+		word := strings.ToUpper(tok.text)
+		switch {
+		case tok.quoted:
+			return decl, pos, fmt.Errorf("unexpected %s", tok.text)
+		case tok.text == "(":
+			end := closingParen(tokens, pos)
+			for _, arg := range tokens[pos+1 : end] {
+				if arg.text != "," {
+					decl.args = append(decl.args, strings.ToUpper(arg.text))
+				}
+			}
+			pos = end + 1
+			continue
+		case tok.text == "[":
+			decl.array = true
+			pos = closingParen(tokens, pos) + 1
+			continue
+		case (word == "WITH" || word == "WITHOUT") && pos+1 < len(tokens) &&
+			(strings.EqualFold(tokens[pos+1].text, "TIME") || strings.EqualFold(tokens[pos+1].text, "LOCAL")):
+			// part of the type, as in TIMESTAMP WITH TIME ZONE
+		case word == "ARRAY" && decl.name != "":
+			decl.array = true
+			pos++
+			continue
+		default:
+			if _, found := ddlConstraintWords[word]; found || !isDDLWord(tok.text) {
+				break typeWords
+			}
+		}
+		if decl.name != "" {
+			decl.name += " "
+		}
+		decl.name += word
+		pos++
+	}
+	return decl, pos, nil
+}
+
+// sizes returns the numeric arguments, and whether one of them is MAX
+func (decl *sqlTypeDecl) sizes() (numbers []int64, hasMax bool) {
+	for _, arg := range decl.args {
+		if arg == "MAX" {
+			hasMax = true
+			continue
+		}
+		if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	return
+}
+
+// class tells which type arguments are a length and which a precision and scale
+func (decl *sqlTypeDecl) class() string {
+	switch decl.name {
+	case "NUMBER", "DECIMAL", "NUMERIC", "DEC", "FIXED", "MONEY", "SMALLMONEY":
+		return "decimal"
+	case "VARCHAR", "CHAR", "CHARACTER", "CHARACTER VARYING", "CHAR VARYING", "NCHAR", "NVARCHAR",
+		"NVARCHAR2", "NCHAR VARYING", "STRING", "TEXT", "BPCHAR":
+		return "text"
+	}
+	return ""
+}
+
+func (decl *sqlTypeDecl) goType(dbType DatabaseType, cfg typeConfig) (reflect.Type, error) {
+	if decl.array && dbType != DbTypePostgresSQL {
+		return nil, fmt.Errorf("array type %s is not supported", decl.name)
+	}
+	if element, isArray := postgresArrayElement(*decl); isArray && dbType == DbTypePostgresSQL {
+		// lib/pq scans arrays through pq.Int64Array, Float64Array, BoolArray and
+		// StringArray, so the element type picks one of those
+		elemDecl := sqlTypeDecl{name: element, args: decl.args}
+		elemType, err := elemDecl.goType(dbType, cfg)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case isIntKind(elemType.Kind()):
+			return baseTypes["[]int64"], nil
+		case elemType.Kind() == reflect.Float32 || elemType.Kind() == reflect.Float64:
+			return baseTypes["[]float64"], nil
+		case elemType.Kind() == reflect.Bool:
+			return baseTypes["[]bool"], nil
+		}
+		return baseTypes["[]string"], nil
+	}
+
+	var names map[string]string
+	switch dbType {
+	case DbTypeSnowflake:
+		names = goTypesSnowflake
+	case DbTypePostgresSQL:
+		names = goTypesPostgres
+	case DbTypeMsSQL:
+		names = goTypesMsSql
+	}
+	name, found := names[decl.name]
+	if !found {
+		return nil, fmt.Errorf("type %s has no Go type", decl.name)
+	}
+
+	numbers, _ := decl.sizes()
+	switch {
+	case dbType == DbTypePostgresSQL && decl.name == "MONEY":
+		// the text carries a currency symbol and separators, so it does not scan as a number
+	case decl.class() == "decimal":
+		// MONEY always has a fraction, and unconstrained NUMERIC takes any scale
+		if len(numbers) > 1 && numbers[1] > 0 || strings.HasSuffix(decl.name, "MONEY") ||
+			dbType == DbTypePostgresSQL && len(numbers) == 0 {
+			return cfg.decimalType, nil
+		}
+		// a whole number wider than 18 digits overflows int64; gosnowflake still
+		// scans Snowflake's NUMBER(38,0) integers as int64
+		if len(numbers) > 0 && numbers[0] > 18 && dbType != DbTypeSnowflake {
+			return cfg.decimalType, nil
+		}
+	case decl.name == "FLOAT" && dbType != DbTypeSnowflake:
+		// FLOAT(n) holds n bits of mantissa
+		if len(numbers) > 0 && numbers[0] <= 24 {
+			name = "float32"
+		}
+	}
+	return baseTypes[name], nil
+}
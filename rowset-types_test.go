@@ -0,0 +1,101 @@
+package sqlrows
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoTypeFor(t *testing.T) {
+	stringType := reflect.TypeOf("")
+	cases := []struct {
+		dbType   DatabaseType
+		sqlType  string
+		expected reflect.Type
+	}{
+		{DbTypeSnowflake, "NUMBER", int64Type},
+		{DbTypeSnowflake, "NUMBER(10,0)", int64Type},
+		{DbTypeSnowflake, "NUMBER(38,0)", int64Type},
+		{DbTypeSnowflake, "number(12, 2)", float64Type},
+		{DbTypeSnowflake, "INTEGER", int64Type},
+		{DbTypeSnowflake, "DOUBLE PRECISION", float64Type},
+		{DbTypeSnowflake, "VARCHAR(64)", stringType},
+		{DbTypeSnowflake, "VARIANT", stringType},
+		{DbTypeSnowflake, "TIMESTAMP_TZ(9)", reflect.TypeOf(time.Time{})},
+		{DbTypeSnowflake, "TIMESTAMP WITH LOCAL TIME ZONE", reflect.TypeOf(time.Time{})},
+		{DbTypePostgresSQL, "INT4", reflect.TypeOf(int32(0))},
+		{DbTypePostgresSQL, "int2", reflect.TypeOf(int16(0))},
+		{DbTypePostgresSQL, "NUMERIC", float64Type},
+		{DbTypePostgresSQL, "NUMERIC(18)", int64Type},
+		{DbTypePostgresSQL, "NUMERIC(20)", float64Type},
+		{DbTypePostgresSQL, "FLOAT(24)", reflect.TypeOf(float32(0))},
+		{DbTypePostgresSQL, "FLOAT(53)", float64Type},
+		{DbTypePostgresSQL, "CHARACTER VARYING(20)", stringType},
+		{DbTypePostgresSQL, "TIMESTAMP(3) WITH TIME ZONE", reflect.TypeOf(time.Time{})},
+		{DbTypePostgresSQL, "UUID", reflect.TypeOf(uuid.UUID{})},
+		{DbTypePostgresSQL, "BYTEA", reflect.TypeOf([]byte(nil))},
+		{DbTypePostgresSQL, "INT[]", reflect.TypeOf([]int64(nil))},
+		{DbTypePostgresSQL, "_INT2", reflect.TypeOf([]int64(nil))},
+		{DbTypePostgresSQL, "_INT8", reflect.TypeOf([]int64(nil))},
+		{DbTypePostgresSQL, "REAL[]", reflect.TypeOf([]float64(nil))},
+		{DbTypePostgresSQL, "BOOLEAN[]", reflect.TypeOf([]bool(nil))},
+		{DbTypePostgresSQL, "UUID[]", reflect.TypeOf([]string(nil))},
+		{DbTypePostgresSQL, "VARCHAR(20) ARRAY", reflect.TypeOf([]string(nil))},
+		{DbTypePostgresSQL, "TSTZRANGE", stringType},
+		{DbTypePostgresSQL, "MONEY", stringType},
+		{DbTypeMsSQL, "TINYINT", reflect.TypeOf(uint8(0))},
+		{DbTypeMsSQL, "DECIMAL(19,4)", float64Type},
+		{DbTypeMsSQL, "DECIMAL(10)", int64Type},
+		{DbTypeMsSQL, "DECIMAL(20,0)", float64Type},
+		{DbTypeMsSQL, "MONEY", float64Type},
+		{DbTypeMsSQL, "NVARCHAR(MAX)", stringType},
+		{DbTypeMsSQL, "DATETIMEOFFSET(7)", reflect.TypeOf(time.Time{})},
+		{DbTypeMsSQL, "UNIQUEIDENTIFIER", reflect.TypeOf(uuid.UUID{})},
+	}
+	for _, c := range cases {
+		actual, err := GoTypeFor(c.dbType, c.sqlType, false)
+		require.NoError(t, err, c.sqlType)
+		assert.Equal(t, c.expected, actual, c.sqlType)
+
+		actual, err = GoTypeFor(c.dbType, c.sqlType, true)
+		require.NoError(t, err, c.sqlType)
+		assert.Equal(t, reflect.PointerTo(c.expected), actual, c.sqlType)
+	}
+}
+
+func TestGoTypeForDecimalScanType(t *testing.T) {
+	stringType := reflect.TypeOf("")
+	actual, err := GoTypeFor(DbTypeSnowflake, "NUMBER(38,6)", true, DecimalScanType(stringType))
+	require.NoError(t, err)
+	assert.Equal(t, reflect.PointerTo(stringType), actual)
+
+	// integers are not affected
+	actual, err = GoTypeFor(DbTypeSnowflake, "NUMBER(38,0)", false, DecimalScanType(stringType))
+	require.NoError(t, err)
+	assert.Equal(t, int64Type, actual)
+}
+
+func TestGoTypeForErrors(t *testing.T) {
+	cases := []struct {
+		dbType  DatabaseType
+		sqlType string
+		err     string
+	}{
+		{DbTypePostgresSQL, "TSVECTOR", "type TSVECTOR has no Go type"},
+		{DbTypeSnowflake, "UUID", "type UUID has no Go type"},
+		{DbTypeSnowflake, "INT[]", "array type INT is not supported"},
+		{DbTypePostgresSQL, "_TSVECTOR", "type TSVECTOR has no Go type"},
+		{DbTypePostgresSQL, "", `invalid SQL type "": unexpected text`},
+		{DbTypePostgresSQL, "INT NOT NULL", `invalid SQL type "INT NOT NULL": unexpected text`},
+		{DbTypeMsSQL, `"INT"`, `invalid SQL type "\"INT\"": unexpected INT`},
+		{DatabaseType(99), "INT", "invalid database type"},
+	}
+	for _, c := range cases {
+		_, err := GoTypeFor(c.dbType, c.sqlType, false)
+		assert.EqualError(t, err, c.err, c.sqlType)
+	}
+}
@@ -0,0 +1,335 @@
+package sqlrows
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// readRow scans the current row of [rs] into a slice of [n] values. Pointer values,
+// as mock row sets hold for nullable columns, are dereferenced so callers only see
+// plain values or nil.
+func readRow(rs RowSet, n int) ([]any, error) {
+	holders := make([]any, n)
+	dest := make([]any, n)
+	for i := range dest {
+		dest[i] = &holders[i]
+	}
+
+	if err := rs.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	vals := make([]any, n)
+	for i, v := range holders {
+		vals[i] = normalizeValue(v)
+	}
+	return vals, nil
+}
+
+// normalizeValue dereferences pointer values, turning nil pointers into nil
+func normalizeValue(v any) any {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface()
+}
+
+// assignRow stores [vals] into the scan destinations, following the conversion
+// rules of database/sql closely enough for test fixtures. Errors read as sql.Rows
+// words them, naming the column from [columns].
+func assignRow(dest []any, vals []any, columns []string) error {
+	if len(dest) != len(vals) {
+		return fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", len(vals), len(dest))
+	}
+	for i := range vals {
+		if err := assignValue(dest[i], vals[i]); err != nil {
+			return fmt.Errorf("sql: Scan error on column index %d, name %q: %w", i, columns[i], err)
+		}
+	}
+	return nil
+}
+
+// Stores [src] into the pointer [dest] using the conversion rules that mock row
+// sets apply in Scan, which follow database/sql. Packages that implement RowSet
+// over other sources use it to scan the same way.
+func ConvertAssign(dest, src any) error {
+	return assignValue(dest, src)
+}
+
+func assignValue(dest, src any) error {
+	src = normalizeValue(src)
+
+	dpv := reflect.ValueOf(dest)
+	if dpv.Kind() != reflect.Pointer || dpv.IsNil() {
+		return errors.New("destination not a pointer")
+	}
+	dv := dpv.Elem()
+
+	if src == nil {
+		if scanner, ok := dest.(sql.Scanner); ok {
+			return scanner.Scan(nil)
+		}
+		switch dv.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			dv.Set(reflect.Zero(dv.Type()))
+			return nil
+		}
+		return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dv.Type()) {
+		if isBytesKind(sv.Type()) {
+			dv.Set(reflect.ValueOf(bytes.Clone(sv.Bytes())).Convert(sv.Type()))
+			return nil
+		}
+		dv.Set(sv)
+		return nil
+	}
+
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+
+	if dv.Kind() == reflect.Pointer {
+		nv := reflect.New(dv.Type().Elem())
+		if err := assignValue(nv.Interface(), src); err != nil {
+			return err
+		}
+		dv.Set(nv)
+		return nil
+	}
+
+	str := valueString(src)
+	switch dv.Kind() {
+	case reflect.String:
+		dv.SetString(str)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, str, dv.Kind(), numErr(err))
+		}
+		dv.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, str, dv.Kind(), numErr(err))
+		}
+		dv.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, str, dv.Kind(), numErr(err))
+		}
+		dv.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a bool: %w", src, str, err)
+		}
+		dv.SetBool(b)
+		return nil
+	case reflect.Slice:
+		// includes named byte slices such as json.RawMessage
+		if isBytesKind(dv.Type()) {
+			dv.SetBytes([]byte(str))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, dest)
+}
+
+// numErr drops the function name and input from a strconv error, leaving the
+// wording database/sql uses
+func numErr(err error) error {
+	if ne, ok := err.(*strconv.NumError); ok {
+		return ne.Err
+	}
+	return err
+}
+
+// valueString renders a value the way a driver would deliver it as text
+func valueString(v any) string {
+	switch tv := normalizeValue(v).(type) {
+	case nil:
+		return ""
+	case string:
+		return tv
+	case []byte:
+		return string(tv)
+	case time.Time:
+		return tv.Format(time.RFC3339Nano)
+	case uuid.UUID:
+		return tv.String()
+	case float32:
+		return strconv.FormatFloat(float64(tv), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(tv, 'g', -1, 64)
+	case fmt.Stringer:
+		return tv.String()
+	default:
+		if rv := reflect.ValueOf(tv); isBytesKind(rv.Type()) {
+			return string(rv.Bytes())
+		}
+		return fmt.Sprint(tv)
+	}
+}
+
+// isBytesKind tells whether [t] is []byte or a named type of it, such as json.RawMessage
+func isBytesKind(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// valueKey produces a string that is equal for equal values, used to hash rows
+func valueKey(v any) string {
+	v = normalizeValue(v)
+	switch tv := v.(type) {
+	case nil:
+		return "\x00null"
+	case time.Time:
+		return "time:" + tv.UTC().Format(time.RFC3339Nano)
+	}
+
+	// integers are keyed exactly, and whole floats as the integer they equal,
+	// so 1 and 1.0 still match but BIGINTs above 2^53 stay distinct
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "num:" + strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "num:" + strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return "num:" + strconv.FormatInt(int64(f), 10)
+		}
+		return "num:" + strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return fmt.Sprintf("%T:%s", v, valueString(v))
+}
+
+func rowKey(vals []any) string {
+	var sb strings.Builder
+	for i, v := range vals {
+		if i > 0 {
+			sb.WriteByte(0x1f)
+		}
+		sb.WriteString(valueKey(v))
+	}
+	return sb.String()
+}
+
+// numericValue reports the value as a float64 when it is a Go number
+func numericValue(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// compareValues orders two non-nil values, returning -1, 0 or 1. Numbers compare
+// by value regardless of their Go type; unrelated types compare by their text.
+func compareValues(a, b any) int {
+	a = normalizeValue(a)
+	b = normalizeValue(b)
+
+	ra := reflect.ValueOf(a)
+	rb := reflect.ValueOf(b)
+	if isIntKind(ra.Kind()) && isIntKind(rb.Kind()) {
+		return compareInts(ra, rb)
+	}
+	if fa, ok := numericValue(a); ok {
+		if fb, ok := numericValue(b); ok {
+			return cmp.Compare(fa, fb)
+		}
+	}
+
+	switch ta := a.(type) {
+	case string:
+		if tb, ok := b.(string); ok {
+			return strings.Compare(ta, tb)
+		}
+	case []byte:
+		if tb, ok := b.([]byte); ok {
+			return bytes.Compare(ta, tb)
+		}
+	case time.Time:
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb)
+		}
+	case bool:
+		if tb, ok := b.(bool); ok {
+			switch {
+			case ta == tb:
+				return 0
+			case !ta:
+				return -1
+			default:
+				return 1
+			}
+		}
+	case uuid.UUID:
+		if tb, ok := b.(uuid.UUID); ok {
+			return bytes.Compare(ta[:], tb[:])
+		}
+	}
+
+	return strings.Compare(valueString(a), valueString(b))
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func compareInts(a, b reflect.Value) int {
+	aSigned := a.CanInt()
+	bSigned := b.CanInt()
+	switch {
+	case aSigned && bSigned:
+		return cmp.Compare(a.Int(), b.Int())
+	case !aSigned && !bSigned:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case aSigned:
+		if a.Int() < 0 || b.Uint() > math.MaxInt64 {
+			return -1
+		}
+		return cmp.Compare(a.Int(), int64(b.Uint()))
+	default:
+		return -compareInts(b, a)
+	}
+}